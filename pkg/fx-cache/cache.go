@@ -0,0 +1,286 @@
+package fxcache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/fredsh/go-fxtend/pkg/fx"
+)
+
+// EvictionReason tells why an entry left the cache.
+type EvictionReason int
+
+const (
+	// ReasonCapacity means the entry was evicted to make room for another one.
+	ReasonCapacity EvictionReason = iota
+	// ReasonExpired means the entry outlived its TTL.
+	ReasonExpired
+	// ReasonRemoved means the entry was deleted or the cache purged.
+	ReasonRemoved
+)
+
+func (r EvictionReason) String() string {
+	switch r {
+	case ReasonCapacity:
+		return "capacity"
+	case ReasonExpired:
+		return "expired"
+	case ReasonRemoved:
+		return "removed"
+	}
+	return "unknown"
+}
+
+// Config configures a Cache.
+type Config[K comparable, V any] struct {
+	Capacity int                                         // Maximum number of entries, 0 means unbounded. It is split between shards, see Cache.
+	Shards   int                                         // Number of independently locked shards, at most Capacity.
+	Policy   EvictionPolicy                              // Eviction policy applied within each shard.
+	TTL      time.Duration                               // Default lifetime of entries, 0 keeps them forever.
+	OnEvict  func(key K, value V, reason EvictionReason) // Called after an entry left the cache, outside of any lock.
+	Clock    fx.Clock                                    // Defaults to fx.SystemClock.
+	Hash     func(K) uint64                              // Spreads keys over shards, see DefaultConfig.
+}
+
+// DefaultConfig returns an unbounded LRU configuration with 16 shards.
+//
+// Keys whose type is built on a boolean, number, string, pointer or channel are
+// hashed by default. Other key types, such as structs, need a Hash function to
+// be spread over shards and otherwise use a single shard.
+func DefaultConfig[K comparable, V any]() Config[K, V] {
+	return Config[K, V]{
+		Shards: 16,
+		Policy: LRU,
+		Clock:  fx.SystemClock(),
+		Hash:   basicHash[K](),
+	}
+}
+
+// Stats are the counters of a Cache.
+type Stats struct {
+	Hits         uint64
+	Misses       uint64
+	Evictions    uint64 // Entries evicted for capacity or expiry.
+	Loads        uint64 // Loader calls made by GetOrLoad.
+	LoadFailures uint64 // Loader calls which returned a failure.
+}
+
+// HitRatio returns the share of lookups which found a value.
+func (s Stats) HitRatio() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+// Cache is a generic in-memory cache with LRU or LFU eviction and TTL expiry.
+// It is safe for concurrent use, keys are spread over shards locked separately.
+//
+// Eviction is per shard and therefore approximate: Capacity is split evenly
+// between shards, every shard holding at least one entry, and a full shard
+// evicts its own least recently or least frequently used entry even when other
+// shards have room. Use a single shard for an exact global LRU or LFU order.
+type Cache[K comparable, V any] struct {
+	config Config[K, V]
+	shards []*shard[K, V]
+
+	hits, misses, evictions, loads, loadFailures atomic.Uint64
+}
+
+// New creates a Cache configured from DefaultConfig and the given enhancers.
+func New[K comparable, V any](opts ...fx.OptionEnhancer[Config[K, V]]) *Cache[K, V] {
+	config := fx.OptionBuilder(DefaultConfig[K, V], opts...)
+	if config.Clock == nil {
+		config.Clock = fx.SystemClock()
+	}
+	if config.Hash == nil {
+		config.Hash = basicHash[K]()
+	}
+	if config.Hash == nil || config.Shards < 1 {
+		config.Shards = 1
+	}
+	if config.Capacity > 0 && config.Shards > config.Capacity {
+		config.Shards = config.Capacity
+	}
+
+	c := &Cache[K, V]{
+		config: config,
+		shards: make([]*shard[K, V], config.Shards),
+	}
+	for i := range c.shards {
+		capacity := 0
+		if config.Capacity > 0 {
+			capacity = config.Capacity / config.Shards
+			if i < config.Capacity%config.Shards {
+				capacity++
+			}
+		}
+		c.shards[i] = newShard[K, V](capacity, config.Policy)
+	}
+	return c
+}
+
+func (c *Cache[K, V]) shardFor(key K) *shard[K, V] {
+	if len(c.shards) == 1 {
+		return c.shards[0]
+	}
+	return c.shards[c.config.Hash(key)%uint64(len(c.shards))]
+}
+
+// Get returns the value stored for key, or None if it is missing or expired.
+func (c *Cache[K, V]) Get(key K) fx.Maybe[V] {
+	var evicted []eviction[K, V]
+	value, ok := c.shardFor(key).get(key, c.config.Clock.Now(), &evicted)
+	c.notify(evicted)
+	if !ok {
+		c.misses.Add(1)
+		return fx.NewNone[V]()
+	}
+	c.hits.Add(1)
+	return fx.NewSome(value)
+}
+
+// Set stores value for key with the default TTL.
+func (c *Cache[K, V]) Set(key K, value V) {
+	c.SetWithTTL(key, value, c.config.TTL)
+}
+
+// SetWithTTL stores value for key, expiring after ttl. A ttl of 0 keeps it forever.
+func (c *Cache[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = c.config.Clock.Now().Add(ttl)
+	}
+	var evicted []eviction[K, V]
+	c.shardFor(key).set(key, value, expiresAt, &evicted)
+	c.notify(evicted)
+}
+
+// Delete removes key from the cache and reports whether it was present.
+func (c *Cache[K, V]) Delete(key K) bool {
+	var evicted []eviction[K, V]
+	found := c.shardFor(key).delete(key, &evicted)
+	c.notify(evicted)
+	return found
+}
+
+// DeleteExpired removes every expired entry. Expired entries are otherwise only
+// removed when looked up.
+func (c *Cache[K, V]) DeleteExpired() {
+	now := c.config.Clock.Now()
+	for _, s := range c.shards {
+		var evicted []eviction[K, V]
+		s.deleteExpired(now, &evicted)
+		c.notify(evicted)
+	}
+}
+
+// Purge removes every entry.
+func (c *Cache[K, V]) Purge() {
+	for _, s := range c.shards {
+		var evicted []eviction[K, V]
+		s.purge(&evicted)
+		c.notify(evicted)
+	}
+}
+
+// Len returns the number of entries, including expired ones not removed yet.
+func (c *Cache[K, V]) Len() int {
+	total := 0
+	for _, s := range c.shards {
+		total += s.len()
+	}
+	return total
+}
+
+// Stats returns a snapshot of the counters of the cache.
+func (c *Cache[K, V]) Stats() Stats {
+	return Stats{
+		Hits:         c.hits.Load(),
+		Misses:       c.misses.Load(),
+		Evictions:    c.evictions.Load(),
+		Loads:        c.loads.Load(),
+		LoadFailures: c.loadFailures.Load(),
+	}
+}
+
+// GetOrLoad returns the value stored for key or calls loader to produce it.
+// Concurrent calls for the same key share a single loader call, a successful
+// result is stored while a failure is returned without being cached. Like
+// fx.FlatMapCtx, a cancelled context produces a failure holding ctx.Err(), a
+// caller waiting for another one's loader stops waiting when its context is done
+// and loads by itself when that loader failed with a context error while its own
+// context is still alive.
+func (c *Cache[K, V]) GetOrLoad(ctx context.Context, key K, loader func(context.Context) fx.Result[V]) fx.Result[V] {
+	if ctx.Err() != nil {
+		return fx.NewFailure[V](ctx.Err())
+	}
+	if value, err := c.Get(key).Get(); err == nil {
+		return fx.NewSuccess(value)
+	}
+
+	s := c.shardFor(key)
+	for {
+		// a loader may have stored the value and left since the lookup above
+		var evicted []eviction[K, V]
+		value, found, pending, leader := s.getOrJoin(key, c.config.Clock.Now(), &evicted)
+		c.notify(evicted)
+		if found {
+			return fx.NewSuccess(value)
+		}
+		if leader {
+			return c.load(ctx, s, key, pending, loader)
+		}
+
+		select {
+		case <-pending.done:
+		case <-ctx.Done():
+			return fx.NewFailure[V](ctx.Err())
+		}
+		if !isContextErr(pending.res.AsError()) || ctx.Err() != nil {
+			return pending.res
+		}
+	}
+}
+
+// load calls loader for key and publishes its Result to the callers waiting on
+// call, registered in the shard s.
+func (c *Cache[K, V]) load(ctx context.Context, s *shard[K, V], key K, call *loadCall[V], loader func(context.Context) fx.Result[V]) fx.Result[V] {
+	completed := false
+	defer func() {
+		if !completed {
+			call.res = fx.NewFailure[V](fmt.Errorf("cache loader panicked for key %v", key))
+		}
+		s.endLoad(key)
+		close(call.done)
+	}()
+
+	c.loads.Add(1)
+	res := loader(ctx)
+	completed = true
+	call.res = res
+	if res.IsError() {
+		c.loadFailures.Add(1)
+		return res
+	}
+	c.Set(key, res.Unwrap())
+	return res
+}
+
+func isContextErr(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+func (c *Cache[K, V]) notify(evicted []eviction[K, V]) {
+	for _, e := range evicted {
+		if e.reason != ReasonRemoved {
+			c.evictions.Add(1)
+		}
+		if c.config.OnEvict != nil {
+			c.config.OnEvict(e.key, e.value, e.reason)
+		}
+	}
+}
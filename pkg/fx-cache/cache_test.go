@@ -0,0 +1,309 @@
+package fxcache
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fredsh/go-fxtend/pkg/fx"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.Advance(d)
+	ch := make(chan time.Time, 1)
+	ch <- c.Now()
+	return ch
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+type evicted struct {
+	key    string
+	value  int
+	reason EvictionReason
+}
+
+func newTestCache(policy EvictionPolicy, capacity int, clock fx.Clock, log *[]evicted) *Cache[string, int] {
+	return New(func(c Config[string, int]) Config[string, int] {
+		c.Shards = 1
+		c.Capacity = capacity
+		c.Policy = policy
+		c.Clock = clock
+		c.OnEvict = func(key string, value int, reason EvictionReason) {
+			*log = append(*log, evicted{key, value, reason})
+		}
+		return c
+	})
+}
+
+func TestCacheEviction(t *testing.T) {
+	cases := []struct {
+		name     string
+		policy   EvictionPolicy
+		scenario func(c *Cache[string, int])
+		wantKeys []string
+		evicted  []evicted
+	}{
+		{
+			name:   "LRU evicts the least recently used entry",
+			policy: LRU,
+			scenario: func(c *Cache[string, int]) {
+				c.Set("a", 1)
+				c.Set("b", 2)
+				c.Set("c", 3)
+				c.Get("a")
+				c.Set("d", 4)
+			},
+			wantKeys: []string{"a", "c", "d"},
+			evicted:  []evicted{{"b", 2, ReasonCapacity}},
+		},
+		{
+			name:   "LFU evicts the least frequently used entry",
+			policy: LFU,
+			scenario: func(c *Cache[string, int]) {
+				c.Set("a", 1)
+				c.Set("b", 2)
+				c.Set("c", 3)
+				c.Get("a")
+				c.Get("a")
+				c.Get("b")
+				c.Get("c")
+				c.Get("b")
+				c.Set("d", 4)
+			},
+			wantKeys: []string{"a", "b", "d"},
+			evicted:  []evicted{{"c", 3, ReasonCapacity}},
+		},
+		{
+			name:   "LFU breaks ties with recency",
+			policy: LFU,
+			scenario: func(c *Cache[string, int]) {
+				c.Set("a", 1)
+				c.Set("b", 2)
+				c.Set("c", 3)
+				c.Set("d", 4)
+				c.Set("e", 5)
+			},
+			wantKeys: []string{"c", "d", "e"},
+			evicted:  []evicted{{"a", 1, ReasonCapacity}, {"b", 2, ReasonCapacity}},
+		},
+		{
+			name:   "updating a key does not evict",
+			policy: LRU,
+			scenario: func(c *Cache[string, int]) {
+				c.Set("a", 1)
+				c.Set("b", 2)
+				c.Set("c", 3)
+				c.Set("a", 10)
+			},
+			wantKeys: []string{"a", "b", "c"},
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var log []evicted
+			c := newTestCache(tt.policy, 3, &fakeClock{}, &log)
+			tt.scenario(c)
+			require.Equal(t, len(tt.wantKeys), c.Len())
+			for _, k := range tt.wantKeys {
+				require.True(t, c.Get(k).IsSome(), k)
+			}
+			require.Equal(t, tt.evicted, log)
+		})
+	}
+}
+
+func TestCacheTTLAndStats(t *testing.T) {
+	var log []evicted
+	clock := &fakeClock{}
+	c := New(func(c Config[string, int]) Config[string, int] {
+		c.TTL = time.Minute
+		c.Clock = clock
+		c.OnEvict = func(key string, value int, reason EvictionReason) {
+			log = append(log, evicted{key, value, reason})
+		}
+		return c
+	})
+
+	c.Set("a", 1)
+	c.SetWithTTL("b", 2, 0)
+	c.SetWithTTL("c", 3, time.Hour)
+	require.Equal(t, fx.NewSome(1), c.Get("a"))
+	require.True(t, c.Get("missing").IsNone())
+
+	clock.Advance(time.Minute)
+	require.True(t, c.Get("a").IsNone())
+	require.Equal(t, fx.NewSome(2), c.Get("b"))
+
+	clock.Advance(time.Hour)
+	c.DeleteExpired()
+	require.Equal(t, 1, c.Len())
+	require.True(t, c.Delete("b"))
+	require.False(t, c.Delete("b"))
+
+	c.Set("d", 4)
+	c.Purge()
+	require.Equal(t, 0, c.Len())
+
+	require.Equal(t, []evicted{
+		{"a", 1, ReasonExpired},
+		{"c", 3, ReasonExpired},
+		{"b", 2, ReasonRemoved},
+		{"d", 4, ReasonRemoved},
+	}, log)
+	stats := c.Stats()
+	require.Equal(t, Stats{Hits: 2, Misses: 2, Evictions: 2}, stats)
+	require.Equal(t, 0.5, stats.HitRatio())
+}
+
+func TestCacheSharding(t *testing.T) {
+	c := New(func(c Config[int, string]) Config[int, string] {
+		c.Capacity = 100
+		c.Shards = 8
+		return c
+	})
+	for i := 0; i < 1000; i++ {
+		c.Set(i, strconv.Itoa(i))
+	}
+	require.Equal(t, 100, c.Len())
+
+	small := New(func(c Config[int, string]) Config[int, string] {
+		c.Capacity = 3
+		return c
+	})
+	require.Len(t, small.shards, 3, "every shard holds at least one entry")
+
+	type point struct{ x, y int }
+	points := New[point, int]()
+	require.Len(t, points.shards, 1, "keys without a hash use a single shard")
+	points.Set(point{1, 2}, 3)
+	require.Equal(t, fx.NewSome(3), points.Get(point{1, 2}))
+
+	hashed := New(func(c Config[point, int]) Config[point, int] {
+		c.Hash = func(p point) uint64 { return uint64(p.x) }
+		return c
+	})
+	require.Len(t, hashed.shards, 16)
+	hashed.Set(point{1, 2}, 3)
+	require.Equal(t, fx.NewSome(3), hashed.Get(point{1, 2}))
+
+	type userID string
+	require.NotNil(t, basicHash[userID]())
+	require.Equal(t, defaultHash("42"), defaultHash(userID("42")), "named types hash like their underlying type")
+	require.Equal(t, defaultHash(0.0), defaultHash(-0.0))
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				c.Set(g*1000+i, "v")
+				c.Get(i)
+			}
+		}(g)
+	}
+	wg.Wait()
+	require.LessOrEqual(t, c.Len(), 100)
+}
+
+func TestCacheGetOrLoad(t *testing.T) {
+	c := New[string, int]()
+	ctx := context.Background()
+	errLoad := errors.New("load failed")
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+	loader := func(ctx context.Context) fx.Result[int] {
+		calls.Add(1)
+		<-release
+		return fx.NewSuccess(42)
+	}
+
+	const callers = 10
+	var wg sync.WaitGroup
+	results := make([]fx.Result[int], callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = c.GetOrLoad(ctx, "answer", loader)
+		}(i)
+	}
+	require.Eventually(t, func() bool { return calls.Load() == 1 }, time.Second, time.Millisecond)
+	close(release)
+	wg.Wait()
+	for _, res := range results {
+		require.Equal(t, 42, res.Unwrap())
+	}
+	require.Equal(t, int32(1), calls.Load())
+	require.Equal(t, fx.NewSome(42), c.Get("answer"))
+
+	res := c.GetOrLoad(ctx, "broken", func(context.Context) fx.Result[int] {
+		return fx.NewFailure[int](errLoad)
+	})
+	require.ErrorIs(t, res.AsError(), errLoad)
+	require.True(t, c.Get("broken").IsNone(), "failures are not cached")
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	require.ErrorIs(t, c.GetOrLoad(cancelled, "other", loader).AsError(), context.Canceled)
+
+	stats := c.Stats()
+	require.Equal(t, uint64(2), stats.Loads)
+	require.Equal(t, uint64(1), stats.LoadFailures)
+}
+
+func TestCacheGetOrLoadCancelledLeader(t *testing.T) {
+	c := New[string, int]()
+	var calls atomic.Int32
+	leaderStarted := make(chan struct{})
+	loader := func(ctx context.Context) fx.Result[int] {
+		if calls.Add(1) == 1 {
+			close(leaderStarted)
+			<-ctx.Done()
+			return fx.NewFailure[int](ctx.Err())
+		}
+		return fx.NewSuccess(42)
+	}
+
+	leaderCtx, cancelLeader := context.WithCancel(context.Background())
+	leader := make(chan fx.Result[int])
+	go func() { leader <- c.GetOrLoad(leaderCtx, "answer", loader) }()
+	<-leaderStarted
+
+	follower := make(chan fx.Result[int])
+	go func() { follower <- c.GetOrLoad(context.Background(), "answer", loader) }()
+	require.Eventually(t, func() bool {
+		s := c.shardFor("answer")
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		call, ok := s.inflight["answer"]
+		return ok && call.waiters == 1
+	}, time.Second, time.Millisecond, "the follower waits on the leader")
+	cancelLeader()
+
+	require.ErrorIs(t, (<-leader).AsError(), context.Canceled)
+	require.Equal(t, 42, (<-follower).Unwrap(), "a live follower loads again instead of inheriting the cancellation")
+	require.Equal(t, int32(2), calls.Load())
+}
@@ -0,0 +1,83 @@
+package fxcache
+
+import (
+	"hash/fnv"
+	"math"
+	"reflect"
+)
+
+// basicHash returns a hash function for the keys whose type is built on a
+// boolean, number, string, pointer or channel, and nil for other key types.
+func basicHash[K comparable]() func(K) uint64 {
+	switch reflect.TypeOf((*K)(nil)).Elem().Kind() {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.String,
+		reflect.Pointer, reflect.UnsafePointer, reflect.Chan:
+		return defaultHash[K]
+	}
+	return nil
+}
+
+// defaultHash hashes the keys accepted by basicHash, common key types directly
+// and named types through reflection.
+func defaultHash[K comparable](key K) uint64 {
+	switch k := any(key).(type) {
+	case string:
+		return hashString(k)
+	case int:
+		return mix(uint64(k))
+	case int64:
+		return mix(uint64(k))
+	case int32:
+		return mix(uint64(k))
+	case uint:
+		return mix(uint64(k))
+	case uint64:
+		return mix(k)
+	case uint32:
+		return mix(uint64(k))
+	}
+
+	v := reflect.ValueOf(key)
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			return mix(1)
+		}
+		return mix(0)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return mix(uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return mix(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if f == 0 {
+			// 0 and -0 are equal keys
+			f = 0
+		}
+		return mix(math.Float64bits(f))
+	case reflect.String:
+		return hashString(v.String())
+	case reflect.Pointer, reflect.UnsafePointer, reflect.Chan:
+		return mix(uint64(v.Pointer()))
+	}
+	return 0
+}
+
+func hashString(s string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(s))
+	return h.Sum64()
+}
+
+// mix is the finalizer of splitmix64.
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
@@ -0,0 +1,131 @@
+package fxcache
+
+import (
+	"container/heap"
+	"container/list"
+)
+
+// EvictionPolicy selects which entry is evicted when a shard is full.
+type EvictionPolicy int
+
+const (
+	// LRU evicts the least recently used entry.
+	LRU EvictionPolicy = iota
+	// LFU evicts the least frequently used entry, the least recently used one
+	// among entries used as often.
+	LFU
+)
+
+func (p EvictionPolicy) String() string {
+	switch p {
+	case LRU:
+		return "LRU"
+	case LFU:
+		return "LFU"
+	}
+	return "unknown"
+}
+
+// evictionPolicy keeps track of the entries of a shard to pick eviction victims.
+type evictionPolicy[K comparable, V any] interface {
+	add(e *entry[K, V])
+	touch(e *entry[K, V])
+	remove(e *entry[K, V])
+	victim() *entry[K, V]
+}
+
+func newEvictionPolicy[K comparable, V any](p EvictionPolicy) evictionPolicy[K, V] {
+	if p == LFU {
+		return &lfuPolicy[K, V]{}
+	}
+	return &lruPolicy[K, V]{order: list.New()}
+}
+
+type lruPolicy[K comparable, V any] struct {
+	order *list.List
+}
+
+func (p *lruPolicy[K, V]) add(e *entry[K, V]) {
+	e.elem = p.order.PushFront(e)
+}
+
+func (p *lruPolicy[K, V]) touch(e *entry[K, V]) {
+	p.order.MoveToFront(e.elem)
+}
+
+func (p *lruPolicy[K, V]) remove(e *entry[K, V]) {
+	p.order.Remove(e.elem)
+	e.elem = nil
+}
+
+func (p *lruPolicy[K, V]) victim() *entry[K, V] {
+	back := p.order.Back()
+	if back == nil {
+		return nil
+	}
+	return back.Value.(*entry[K, V])
+}
+
+// lfuPolicy is a min-heap of entries ordered by frequency then recency.
+type lfuPolicy[K comparable, V any] struct {
+	entries []*entry[K, V]
+	tick    uint64
+}
+
+func (p *lfuPolicy[K, V]) add(e *entry[K, V]) {
+	p.tick++
+	e.freq, e.tick = 1, p.tick
+	heap.Push(p, e)
+}
+
+func (p *lfuPolicy[K, V]) touch(e *entry[K, V]) {
+	p.tick++
+	e.freq, e.tick = e.freq+1, p.tick
+	heap.Fix(p, e.index)
+}
+
+func (p *lfuPolicy[K, V]) remove(e *entry[K, V]) {
+	heap.Remove(p, e.index)
+}
+
+func (p *lfuPolicy[K, V]) victim() *entry[K, V] {
+	if len(p.entries) == 0 {
+		return nil
+	}
+	return p.entries[0]
+}
+
+// Len, Less, Swap, Push and Pop implement heap.Interface.
+
+func (p *lfuPolicy[K, V]) Len() int {
+	return len(p.entries)
+}
+
+func (p *lfuPolicy[K, V]) Less(i, j int) bool {
+	a, b := p.entries[i], p.entries[j]
+	if a.freq != b.freq {
+		return a.freq < b.freq
+	}
+	return a.tick < b.tick
+}
+
+func (p *lfuPolicy[K, V]) Swap(i, j int) {
+	p.entries[i], p.entries[j] = p.entries[j], p.entries[i]
+	p.entries[i].index = i
+	p.entries[j].index = j
+}
+
+func (p *lfuPolicy[K, V]) Push(x any) {
+	e := x.(*entry[K, V])
+	e.index = len(p.entries)
+	p.entries = append(p.entries, e)
+}
+
+func (p *lfuPolicy[K, V]) Pop() any {
+	last := len(p.entries) - 1
+	e := p.entries[last]
+	p.entries[last] = nil
+	p.entries = p.entries[:last]
+	e.index = -1
+	return e
+}
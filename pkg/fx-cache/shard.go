@@ -0,0 +1,160 @@
+package fxcache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/fredsh/go-fxtend/pkg/fx"
+)
+
+type entry[K comparable, V any] struct {
+	key       K
+	value     V
+	expiresAt time.Time
+
+	elem  *list.Element // position in the LRU list
+	freq  uint64        // number of uses for LFU
+	tick  uint64        // last use for LFU
+	index int           // position in the LFU heap
+}
+
+func (e *entry[K, V]) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
+
+type eviction[K comparable, V any] struct {
+	key    K
+	value  V
+	reason EvictionReason
+}
+
+type loadCall[V any] struct {
+	done    chan struct{}
+	res     fx.Result[V]
+	waiters int // Callers waiting for res, guarded by shard.mu.
+}
+
+// shard is a part of the cache protected by its own lock, which also guards the
+// loader calls in flight for its keys.
+type shard[K comparable, V any] struct {
+	mu       sync.Mutex
+	items    map[K]*entry[K, V]
+	policy   evictionPolicy[K, V]
+	capacity int
+	inflight map[K]*loadCall[V]
+}
+
+func newShard[K comparable, V any](capacity int, policy EvictionPolicy) *shard[K, V] {
+	return &shard[K, V]{
+		items:    map[K]*entry[K, V]{},
+		policy:   newEvictionPolicy[K, V](policy),
+		capacity: capacity,
+		inflight: map[K]*loadCall[V]{},
+	}
+}
+
+func (s *shard[K, V]) get(key K, now time.Time, evicted *[]eviction[K, V]) (V, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lookup(key, now, evicted)
+}
+
+// getOrJoin returns the value stored for key or, when it is missing, the loader
+// call in flight for it. If there is none, a new call is registered and leader
+// is true: the caller must run the loader and then call endLoad.
+func (s *shard[K, V]) getOrJoin(key K, now time.Time, evicted *[]eviction[K, V]) (value V, found bool, call *loadCall[V], leader bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if value, found = s.lookup(key, now, evicted); found {
+		return value, true, nil, false
+	}
+	if call, ok := s.inflight[key]; ok {
+		call.waiters++
+		return value, false, call, false
+	}
+	call = &loadCall[V]{done: make(chan struct{})}
+	s.inflight[key] = call
+	return value, false, call, true
+}
+
+// endLoad unregisters the loader call in flight for key.
+func (s *shard[K, V]) endLoad(key K) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.inflight, key)
+}
+
+// lookup must be called with mu held.
+func (s *shard[K, V]) lookup(key K, now time.Time, evicted *[]eviction[K, V]) (V, bool) {
+	e, ok := s.items[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	if e.expired(now) {
+		s.remove(e, ReasonExpired, evicted)
+		var zero V
+		return zero, false
+	}
+	s.policy.touch(e)
+	return e.value, true
+}
+
+func (s *shard[K, V]) set(key K, value V, expiresAt time.Time, evicted *[]eviction[K, V]) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e, ok := s.items[key]; ok {
+		e.value, e.expiresAt = value, expiresAt
+		s.policy.touch(e)
+		return
+	}
+	// make room first so that LFU never evicts the entry being added
+	for s.capacity > 0 && len(s.items) >= s.capacity {
+		s.remove(s.policy.victim(), ReasonCapacity, evicted)
+	}
+	e := &entry[K, V]{key: key, value: value, expiresAt: expiresAt}
+	s.items[key] = e
+	s.policy.add(e)
+}
+
+func (s *shard[K, V]) delete(key K, evicted *[]eviction[K, V]) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.items[key]
+	if ok {
+		s.remove(e, ReasonRemoved, evicted)
+	}
+	return ok
+}
+
+func (s *shard[K, V]) deleteExpired(now time.Time, evicted *[]eviction[K, V]) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, e := range s.items {
+		if e.expired(now) {
+			s.remove(e, ReasonExpired, evicted)
+		}
+	}
+}
+
+func (s *shard[K, V]) purge(evicted *[]eviction[K, V]) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, e := range s.items {
+		s.remove(e, ReasonRemoved, evicted)
+	}
+}
+
+func (s *shard[K, V]) len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.items)
+}
+
+// remove must be called with mu held.
+func (s *shard[K, V]) remove(e *entry[K, V], reason EvictionReason, evicted *[]eviction[K, V]) {
+	delete(s.items, e.key)
+	s.policy.remove(e)
+	*evicted = append(*evicted, eviction[K, V]{key: e.key, value: e.value, reason: reason})
+}
@@ -1,8 +1,7 @@
 package fxcollection
 
 import (
-	"fmt"
-
+	fxerror "github.com/fredsh/go-fxtend/pkg/fx-error"
 	fxtypes "github.com/fredsh/go-fxtend/pkg/fx-types"
 )
 
@@ -13,7 +12,7 @@ func ReverseMap[T comparable, U comparable](m map[T]U) (map[U]T, error) {
 	result := make(map[U]T, len(m))
 	for k, v := range m {
 		if _, ok := result[v]; ok {
-			return nil, fmt.Errorf("duplicate value found: %v", v)
+			return nil, fxerror.NewDuplicateValueError(v)
 		}
 		result[v] = k
 	}
@@ -38,7 +37,7 @@ func ReverseMapX[T comparable, U comparable](m map[T]U) fxtypes.Result[map[U]T]
 	result := make(map[U]T, len(m))
 	for k, v := range m {
 		if _, ok := result[v]; ok {
-			return fxtypes.NewErrorResult[map[U]T](fmt.Errorf("duplicate value found: %v", v))
+			return fxtypes.NewErrorResult[map[U]T](fxerror.NewDuplicateValueError(v))
 		}
 		result[v] = k
 	}
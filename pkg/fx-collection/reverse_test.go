@@ -3,6 +3,7 @@ package fxcollection
 import (
 	"testing"
 
+	fxerror "github.com/fredsh/go-fxtend/pkg/fx-error"
 	"github.com/stretchr/testify/require"
 )
 
@@ -66,8 +67,8 @@ func TestReverseMap(t *testing.T) {
 			res, err := ReverseMap(tt.input)
 			resX := ReverseMapX(tt.input)
 			if tt.wantErr {
-				require.Error(t, err)
-				require.Error(t, resX.AsError())
+				require.True(t, fxerror.IsDuplicateValue(err))
+				require.True(t, fxerror.IsDuplicateValue(resX.AsError()))
 			} else {
 				require.NoError(t, err)
 				require.NoError(t, resX.AsError())
@@ -1,8 +1,7 @@
 package fxcollection
 
 import (
-	"fmt"
-
+	fxerror "github.com/fredsh/go-fxtend/pkg/fx-error"
 	fxtypes "github.com/fredsh/go-fxtend/pkg/fx-types"
 )
 
@@ -26,7 +25,7 @@ func ToMap[K comparable, V any](input []V, keySelector func(item V) K) (map[K]V,
 	for _, v := range input {
 		itemKey := keySelector(v)
 		if _, ok := result[itemKey]; ok {
-			return nil, fmt.Errorf("duplicate key found: %v", itemKey)
+			return nil, fxerror.NewDuplicateKeyError(itemKey)
 		}
 		result[itemKey] = v
 	}
@@ -41,7 +40,7 @@ func ToMapX[K comparable, V any](input []V, keySelector func(item V) K) fxtypes.
 	for _, v := range input {
 		itemKey := keySelector(v)
 		if _, ok := result[itemKey]; ok {
-			return fxtypes.NewErrorResult[map[K]V](fmt.Errorf("duplicate key found: %v", itemKey))
+			return fxtypes.NewErrorResult[map[K]V](fxerror.NewDuplicateKeyError(itemKey))
 		}
 		result[itemKey] = v
 	}
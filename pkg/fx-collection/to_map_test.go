@@ -3,6 +3,7 @@ package fxcollection
 import (
 	"testing"
 
+	fxerror "github.com/fredsh/go-fxtend/pkg/fx-error"
 	"github.com/stretchr/testify/require"
 )
 
@@ -80,10 +81,10 @@ func TestToMap(t *testing.T) {
 			resPreparedX := preparedToMapX(tt.input)
 			resX := ToMapX(tt.input, keySelector)
 			if tt.wantErr {
-				require.Error(t, err)
-				require.Error(t, errPrepared)
-				require.Error(t, resX.AsError())
-				require.Error(t, resPreparedX.AsError())
+				require.True(t, fxerror.IsDuplicateKey(err))
+				require.True(t, fxerror.IsDuplicateKey(errPrepared))
+				require.True(t, fxerror.IsDuplicateKey(resX.AsError()))
+				require.True(t, fxerror.IsDuplicateKey(resPreparedX.AsError()))
 			} else {
 				require.NoError(t, err)
 				require.NoError(t, errPrepared)
@@ -0,0 +1,172 @@
+package fxerror
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	ErrDuplicateValue  = errors.New("duplicate value encountered")
+	ErrDuplicateKey    = errors.New("duplicate key encountered")
+	ErrNotFound        = errors.New("not found")
+	ErrInvalidArgument = errors.New("invalid argument")
+	ErrConflict        = errors.New("conflict")
+	ErrTimeout         = errors.New("timeout")
+	ErrUnavailable     = errors.New("unavailable")
+	ErrCircuitOpen     = errors.New("circuit breaker is open")
+)
+
+// Code identifies the category of an error in a stable, comparable way.
+type Code int
+
+const (
+	CodeUnknown Code = iota
+	CodeDuplicateValue
+	CodeDuplicateKey
+	CodeNotFound
+	CodeInvalidArgument
+	CodeConflict
+	CodeTimeout
+	CodeUnavailable
+)
+
+var codeNames = map[Code]string{
+	CodeUnknown:         "unknown",
+	CodeDuplicateValue:  "duplicate_value",
+	CodeDuplicateKey:    "duplicate_key",
+	CodeNotFound:        "not_found",
+	CodeInvalidArgument: "invalid_argument",
+	CodeConflict:        "conflict",
+	CodeTimeout:         "timeout",
+	CodeUnavailable:     "unavailable",
+}
+
+// categories maps every sentinel error to its Code, most specific first.
+var categories = []struct {
+	err  error
+	code Code
+}{
+	{ErrDuplicateValue, CodeDuplicateValue},
+	{ErrDuplicateKey, CodeDuplicateKey},
+	{ErrNotFound, CodeNotFound},
+	{ErrInvalidArgument, CodeInvalidArgument},
+	{ErrConflict, CodeConflict},
+	{ErrTimeout, CodeTimeout},
+	{ErrUnavailable, CodeUnavailable},
+	{ErrCircuitOpen, CodeUnavailable},
+}
+
+// String returns the snake_case name of the code.
+func (c Code) String() string {
+	if name, ok := codeNames[c]; ok {
+		return name
+	}
+	return codeNames[CodeUnknown]
+}
+
+// Error is an error carrying a message and a category, which should be one of
+// the sentinel errors of this package.
+type Error struct {
+	msg string
+	err error
+}
+
+// New creates an Error with the given category and message.
+func New(category error, msg string) *Error {
+	return &Error{
+		msg: msg,
+		err: category,
+	}
+}
+
+// Error returns the message of the error.
+func (e *Error) Error() string {
+	return e.msg
+}
+
+// Unwrap returns the category of the error.
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// Code returns the Code matching the category of the error.
+func (e *Error) Code() Code {
+	return codeOfCategory(e.err)
+}
+
+// CodeOf returns the Code of err. The Code method of the first error in the chain
+// implementing it wins, otherwise err is matched against the sentinel errors and
+// the timeouts recognized by IsTimeout.
+// It returns CodeUnknown if err does not belong to any category.
+func CodeOf(err error) Code {
+	var coded interface{ Code() Code }
+	if errors.As(err, &coded) {
+		return coded.Code()
+	}
+	if code := codeOfCategory(err); code != CodeUnknown {
+		return code
+	}
+	if IsTimeout(err) {
+		return CodeTimeout
+	}
+	return CodeUnknown
+}
+
+func codeOfCategory(err error) Code {
+	if err == nil {
+		return CodeUnknown
+	}
+	for _, c := range categories {
+		if errors.Is(err, c.err) {
+			return c.code
+		}
+	}
+	return CodeUnknown
+}
+
+// IsDuplicateValue reports whether err is caused by a duplicate value.
+func IsDuplicateValue(err error) bool {
+	return errors.Is(err, ErrDuplicateValue)
+}
+
+// IsDuplicateKey reports whether err is caused by a duplicate key.
+func IsDuplicateKey(err error) bool {
+	return errors.Is(err, ErrDuplicateKey)
+}
+
+// IsNotFound reports whether err is caused by a missing value.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsInvalidArgument reports whether err is caused by an invalid argument.
+func IsInvalidArgument(err error) bool {
+	return errors.Is(err, ErrInvalidArgument)
+}
+
+// IsConflict reports whether err is caused by a conflict, duplicate values and
+// keys are considered conflicts.
+func IsConflict(err error) bool {
+	return errors.Is(err, ErrConflict) || IsDuplicateValue(err) || IsDuplicateKey(err)
+}
+
+// IsTimeout reports whether err is caused by a timeout, including an exceeded
+// context deadline and errors reporting themselves as timeouts.
+func IsTimeout(err error) bool {
+	if errors.Is(err, ErrTimeout) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var timeout interface{ Timeout() bool }
+	return errors.As(err, &timeout) && timeout.Timeout()
+}
+
+// IsUnavailable reports whether err is caused by an unavailable resource, an
+// open circuit breaker is considered unavailable.
+func IsUnavailable(err error) bool {
+	return errors.Is(err, ErrUnavailable) || IsCircuitOpen(err)
+}
+
+// IsCircuitOpen reports whether err was returned by an open circuit breaker.
+func IsCircuitOpen(err error) bool {
+	return errors.Is(err, ErrCircuitOpen)
+}
@@ -0,0 +1,138 @@
+package fxerror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassification(t *testing.T) {
+	cases := []struct {
+		name     string
+		err      error
+		wantCode Code
+		is       []func(error) bool
+		isNot    []func(error) bool
+	}{
+		{
+			name:     "nil error is unknown",
+			err:      nil,
+			wantCode: CodeUnknown,
+			isNot:    []func(error) bool{IsNotFound, IsConflict, IsTimeout, IsRetryable},
+		},
+		{
+			name:     "uncategorized error is unknown",
+			err:      errors.New("boom"),
+			wantCode: CodeUnknown,
+			isNot:    []func(error) bool{IsNotFound, IsConflict, IsTimeout, IsUnavailable, IsInvalidArgument, IsRetryable},
+		},
+		{
+			name:     "duplicate value is a conflict",
+			err:      NewDuplicateValueError(1),
+			wantCode: CodeDuplicateValue,
+			is:       []func(error) bool{IsDuplicateValue, IsConflict},
+			isNot:    []func(error) bool{IsDuplicateKey, IsNotFound},
+		},
+		{
+			name:     "wrapped duplicate key is a conflict",
+			err:      fmt.Errorf("building index: %w", NewDuplicateKeyError("a")),
+			wantCode: CodeDuplicateKey,
+			is:       []func(error) bool{IsDuplicateKey, IsConflict},
+			isNot:    []func(error) bool{IsDuplicateValue},
+		},
+		{
+			name:     "merge conflict is a conflict",
+			err:      NewConflictError("k", 1, 2, 3),
+			wantCode: CodeConflict,
+			is:       []func(error) bool{IsConflict},
+			isNot:    []func(error) bool{IsDuplicateKey, IsDuplicateValue},
+		},
+		{
+			name:     "value error with a category",
+			err:      NewValueError(ErrInvalidArgument, -1),
+			wantCode: CodeInvalidArgument,
+			is:       []func(error) bool{IsInvalidArgument},
+			isNot:    []func(error) bool{IsConflict},
+		},
+		{
+			name:     "categorized error",
+			err:      New(ErrNotFound, "no such user"),
+			wantCode: CodeNotFound,
+			is:       []func(error) bool{IsNotFound},
+		},
+		{
+			name:     "wrapped sentinel",
+			err:      fmt.Errorf("calling backend: %w", ErrUnavailable),
+			wantCode: CodeUnavailable,
+			is:       []func(error) bool{IsUnavailable, IsRetryable},
+			isNot:    []func(error) bool{IsTimeout},
+		},
+		{
+			name:     "open circuit is unavailable",
+			err:      NewValueError(ErrCircuitOpen, "payments"),
+			wantCode: CodeUnavailable,
+			is:       []func(error) bool{IsCircuitOpen, IsUnavailable, IsRetryable},
+		},
+		{
+			name:     "conflict sentinel",
+			err:      ErrConflict,
+			wantCode: CodeConflict,
+			is:       []func(error) bool{IsConflict},
+			isNot:    []func(error) bool{IsDuplicateValue},
+		},
+		{
+			name:     "timeout sentinel",
+			err:      New(ErrTimeout, "too slow"),
+			wantCode: CodeTimeout,
+			is:       []func(error) bool{IsTimeout, IsRetryable},
+		},
+		{
+			name:     "context deadline is a timeout",
+			err:      context.DeadlineExceeded,
+			wantCode: CodeTimeout,
+			is:       []func(error) bool{IsTimeout},
+		},
+		{
+			name:     "error marked retryable",
+			err:      fmt.Errorf("calling: %w", MarkRetryable(New(ErrConflict, "version mismatch"))),
+			wantCode: CodeConflict,
+			is:       []func(error) bool{IsRetryable, IsConflict},
+		},
+		{
+			name:     "error marked permanent overrides its category",
+			err:      MarkPermanent(ErrUnavailable),
+			wantCode: CodeUnavailable,
+			is:       []func(error) bool{IsUnavailable},
+			isNot:    []func(error) bool{IsRetryable},
+		},
+		{
+			name:     "error reporting itself as a timeout",
+			err:      fmt.Errorf("reading: %w", os.ErrDeadlineExceeded),
+			wantCode: CodeTimeout,
+			is:       []func(error) bool{IsTimeout},
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.wantCode, CodeOf(tt.err))
+			for _, is := range tt.is {
+				require.True(t, is(tt.err))
+			}
+			for _, isNot := range tt.isNot {
+				require.False(t, isNot(tt.err))
+			}
+		})
+	}
+}
+
+func TestErrorMessages(t *testing.T) {
+	require.EqualError(t, NewDuplicateValueError(1), "duplicate value encountered: [1]")
+	require.EqualError(t, NewValueError(nil, 1), "non-categorized issue encountered with value: [1]")
+	require.EqualError(t, New(ErrNotFound, "no such user"), "no such user")
+	require.Equal(t, "not_found", CodeNotFound.String())
+	require.Equal(t, "unknown", Code(-1).String())
+}
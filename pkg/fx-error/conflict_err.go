@@ -0,0 +1,39 @@
+package fxerror
+
+import (
+	"fmt"
+)
+
+// ConflictError reports a key changed differently by both sides of a three-way
+// merge. It belongs to the ErrConflict category.
+type ConflictError struct {
+	Key    interface{} // The conflicting key.
+	Base   interface{} // The value of the key in the common ancestor.
+	Ours   interface{} // The value of the key on our side.
+	Theirs interface{} // The value of the key on their side.
+}
+
+// NewConflictError creates a ConflictError for key.
+func NewConflictError(key, base, ours, theirs interface{}) *ConflictError {
+	return &ConflictError{
+		Key:    key,
+		Base:   base,
+		Ours:   ours,
+		Theirs: theirs,
+	}
+}
+
+// Error returns the error message for ConflictError.
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%s: [%v] base [%v] ours [%v] theirs [%v]", ErrConflict.Error(), e.Key, e.Base, e.Ours, e.Theirs)
+}
+
+// Unwrap returns ErrConflict.
+func (e *ConflictError) Unwrap() error {
+	return ErrConflict
+}
+
+// Code returns CodeConflict.
+func (e *ConflictError) Code() Code {
+	return CodeConflict
+}
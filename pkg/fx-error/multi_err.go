@@ -0,0 +1,73 @@
+package fxerror
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// MultiError aggregates the errors of several operations.
+type MultiError struct {
+	Errors []error // The aggregated errors, never nil.
+}
+
+// NewMultiError aggregates the non-nil errors of errs. It returns nil if there is
+// none.
+func NewMultiError(errs ...error) error {
+	filtered := make([]error, 0, len(errs))
+	for _, err := range errs {
+		if err != nil {
+			filtered = append(filtered, err)
+		}
+	}
+	if len(filtered) == 0 {
+		return nil
+	}
+	return &MultiError{Errors: filtered}
+}
+
+// Error returns the messages of the aggregated errors.
+func (e *MultiError) Error() string {
+	if len(e.Errors) == 1 {
+		return e.Errors[0].Error()
+	}
+	var b strings.Builder
+	b.WriteString(strconv.Itoa(len(e.Errors)))
+	b.WriteString(" errors occurred: ")
+	for i, err := range e.Errors {
+		if i > 0 {
+			b.WriteString("; ")
+		}
+		b.WriteString(err.Error())
+	}
+	return b.String()
+}
+
+// Is reports whether any of the aggregated errors matches target. errors.Is
+// only walks Unwrap() []error since Go 1.20, Is makes it work with older
+// versions.
+func (e *MultiError) Is(target error) bool {
+	for _, err := range e.Errors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first aggregated error matching target, like errors.As. It is
+// needed before Go 1.20 for the same reason as Is.
+func (e *MultiError) As(target any) bool {
+	for _, err := range e.Errors {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Unwrap returns the aggregated errors so that errors.Is and errors.As match
+// any of them.
+func (e *MultiError) Unwrap() []error {
+	return e.Errors
+}
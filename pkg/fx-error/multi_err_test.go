@@ -0,0 +1,48 @@
+package fxerror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiError(t *testing.T) {
+	require.NoError(t, NewMultiError())
+	require.NoError(t, NewMultiError(nil, nil))
+
+	errA := errors.New("a")
+	single := NewMultiError(nil, errA)
+	require.EqualError(t, single, "a")
+
+	multi := NewMultiError(errA, NewDuplicateValueError(1))
+	require.EqualError(t, multi, "2 errors occurred: a; duplicate value encountered: [1]")
+	require.ErrorIs(t, multi, errA)
+	require.True(t, IsDuplicateValue(multi))
+
+	var valueErr *ValueError
+	require.ErrorAs(t, multi, &valueErr)
+	require.Equal(t, 1, valueErr.Value)
+}
+
+// TestMultiErrorIsAs calls Is and As directly, which is all errors.Is and
+// errors.As rely on before Go 1.20.
+func TestMultiErrorIsAs(t *testing.T) {
+	errA := errors.New("a")
+	multi := &MultiError{Errors: []error{errA, fmt.Errorf("wrapped: %w", NewDuplicateKeyError("k"))}}
+
+	require.True(t, multi.Is(errA))
+	require.True(t, multi.Is(ErrDuplicateKey))
+	require.False(t, multi.Is(ErrNotFound))
+
+	var valueErr *ValueError
+	require.True(t, multi.As(&valueErr))
+	require.Equal(t, "k", valueErr.Value)
+	var missing *MultiError
+	require.False(t, (&MultiError{Errors: []error{errA}}).As(&missing))
+
+	wrapped := fmt.Errorf("outer: %w", multi)
+	require.ErrorIs(t, wrapped, errA)
+	require.True(t, IsDuplicateKey(wrapped))
+}
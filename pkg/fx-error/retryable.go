@@ -0,0 +1,59 @@
+package fxerror
+
+import (
+	"errors"
+)
+
+// Retryable is implemented by errors that know whether the operation which
+// produced them can be attempted again.
+type Retryable interface {
+	Retryable() bool
+}
+
+// retryableError overrides the retry classification of the error it wraps.
+type retryableError struct {
+	err       error
+	retryable bool
+}
+
+// MarkRetryable wraps err so that IsRetryable reports true for it.
+func MarkRetryable(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &retryableError{err: err, retryable: true}
+}
+
+// MarkPermanent wraps err so that IsRetryable reports false for it.
+func MarkPermanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &retryableError{err: err, retryable: false}
+}
+
+func (e *retryableError) Error() string {
+	return e.err.Error()
+}
+
+func (e *retryableError) Unwrap() error {
+	return e.err
+}
+
+func (e *retryableError) Retryable() bool {
+	return e.retryable
+}
+
+// IsRetryable reports whether the operation which produced err can be attempted
+// again. The first error in the chain implementing Retryable decides, otherwise
+// timeouts and unavailable errors are considered retryable.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	var retryable Retryable
+	if errors.As(err, &retryable) {
+		return retryable.Retryable()
+	}
+	return IsTimeout(err) || IsUnavailable(err)
+}
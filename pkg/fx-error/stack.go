@@ -0,0 +1,109 @@
+package fxerror
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"sync/atomic"
+)
+
+const maxStackDepth = 32
+
+var captureStacks atomic.Bool
+
+// SetCaptureStacks enables or disables the automatic stack capture performed by
+// the constructors of failures such as fx.NewFailure. It is disabled by default,
+// in which case those constructors only pay for an atomic load.
+func SetCaptureStacks(enabled bool) {
+	captureStacks.Store(enabled)
+}
+
+// CaptureStacks reports whether automatic stack capture is enabled.
+func CaptureStacks() bool {
+	return captureStacks.Load()
+}
+
+// StackError wraps an error with the stack of its caller at creation time.
+type StackError struct {
+	err error
+	pcs []uintptr
+}
+
+// WithStack wraps err with the stack of its caller. It returns nil if err is nil
+// and err itself if a stack was already captured in its chain.
+func WithStack(err error) error {
+	return withStack(err, 1)
+}
+
+// WithStackSkip is like WithStack but skips the given number of additional
+// frames, so that helpers can record the stack of their own caller.
+func WithStackSkip(err error, skip int) error {
+	return withStack(err, skip+1)
+}
+
+func withStack(err error, skip int) error {
+	if err == nil {
+		return nil
+	}
+	var stackErr *StackError
+	if errors.As(err, &stackErr) {
+		return err
+	}
+
+	pcs := make([]uintptr, maxStackDepth)
+	// skip runtime.Callers and withStack itself
+	n := runtime.Callers(skip+2, pcs)
+	return &StackError{
+		err: err,
+		pcs: pcs[:n],
+	}
+}
+
+// Error returns the message of the wrapped error.
+func (e *StackError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (e *StackError) Unwrap() error {
+	return e.err
+}
+
+// StackTrace returns the frames recorded when the error was created, innermost
+// first.
+func (e *StackError) StackTrace() []runtime.Frame {
+	if len(e.pcs) == 0 {
+		return nil
+	}
+	frames := runtime.CallersFrames(e.pcs)
+	res := make([]runtime.Frame, 0, len(e.pcs))
+	for {
+		frame, more := frames.Next()
+		res = append(res, frame)
+		if !more {
+			break
+		}
+	}
+	return res
+}
+
+// Format implements fmt.Formatter. %+v prints the message followed by the stack
+// trace, one function and location per frame, other verbs only print the message.
+func (e *StackError) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if f.Flag('+') {
+			fmt.Fprintf(f, "%+v", e.err)
+			for _, frame := range e.StackTrace() {
+				fmt.Fprintf(f, "\n%s\n\t%s:%d", frame.Function, frame.File, frame.Line)
+			}
+			return
+		}
+		_, _ = io.WriteString(f, e.Error())
+	case 's':
+		_, _ = io.WriteString(f, e.Error())
+	case 'q':
+		fmt.Fprintf(f, "%q", e.Error())
+	}
+}
@@ -0,0 +1,55 @@
+package fxerror
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithStack(t *testing.T) {
+	require.NoError(t, WithStack(nil))
+
+	base := NewDuplicateValueError(1)
+	err := WithStack(base)
+	require.EqualError(t, err, base.Error())
+	require.ErrorIs(t, err, ErrDuplicateValue)
+	require.True(t, IsDuplicateValue(err))
+
+	var stackErr *StackError
+	require.ErrorAs(t, err, &stackErr)
+	frames := stackErr.StackTrace()
+	require.NotEmpty(t, frames)
+	require.True(t, strings.HasSuffix(frames[0].Function, "/fx-error.TestWithStack"), frames[0].Function)
+
+	wrapped := fmt.Errorf("outer: %w", err)
+	require.Equal(t, wrapped, WithStack(wrapped))
+
+	require.Equal(t, base.Error(), fmt.Sprintf("%v", err))
+	require.Equal(t, base.Error(), fmt.Sprintf("%s", err))
+	require.Equal(t, fmt.Sprintf("%q", base.Error()), fmt.Sprintf("%q", err))
+
+	detailed := fmt.Sprintf("%+v", err)
+	require.True(t, strings.HasPrefix(detailed, base.Error()+"\n"))
+	require.Contains(t, detailed, "/fx-error.TestWithStack")
+	require.Contains(t, detailed, "stack_test.go:")
+}
+
+func helperFailing() error {
+	return WithStackSkip(errors.New("boom"), 1)
+}
+
+func TestWithStackSkip(t *testing.T) {
+	var stackErr *StackError
+	require.ErrorAs(t, helperFailing(), &stackErr)
+	require.True(t, strings.HasSuffix(stackErr.StackTrace()[0].Function, "/fx-error.TestWithStackSkip"))
+}
+
+func TestCaptureStacksToggle(t *testing.T) {
+	require.False(t, CaptureStacks())
+	SetCaptureStacks(true)
+	defer SetCaptureStacks(false)
+	require.True(t, CaptureStacks())
+}
@@ -1,26 +1,34 @@
 package fxerror
 
 import (
-	"errors"
 	"fmt"
 )
 
-var ErrDuplicateValue = errors.New("duplicate value encountered")
-
-// ValueError represents an error that occurs when a duplicate value is encountered.
+// ValueError represents an error caused by a specific value, such as a duplicate
+// value or key, categorized by one of the sentinel errors of this package.
 type ValueError struct {
-	Value interface{} // The duplicate value that caused the error.
+	Value interface{} // The value that caused the error.
 	err   error
 }
 
-func NewDuplicateValueError(value interface{}) *ValueError {
+// NewValueError creates a ValueError for value in the given category, which
+// should be one of the sentinel errors of this package.
+func NewValueError(category error, value interface{}) *ValueError {
 	return &ValueError{
 		Value: value,
-		err:   ErrDuplicateValue,
+		err:   category,
 	}
 }
 
-// Error returns the error message for DuplicateValueError.
+func NewDuplicateValueError(value interface{}) *ValueError {
+	return NewValueError(ErrDuplicateValue, value)
+}
+
+func NewDuplicateKeyError(key interface{}) *ValueError {
+	return NewValueError(ErrDuplicateKey, key)
+}
+
+// Error returns the error message for ValueError.
 func (e *ValueError) Error() string {
 	if e.err == nil {
 		return fmt.Sprintf("non-categorized issue encountered with value: [%v]", e.Value)
@@ -28,7 +36,12 @@ func (e *ValueError) Error() string {
 	return fmt.Sprintf("%s: [%v]", e.err.Error(), e.Value)
 }
 
-// Unwrap returns the wrapped error, which is always nil for DuplicateValueError.
+// Unwrap returns the category of the error.
 func (e *ValueError) Unwrap() error {
 	return e.err
 }
+
+// Code returns the Code matching the category of the error.
+func (e *ValueError) Code() Code {
+	return codeOfCategory(e.err)
+}
@@ -0,0 +1,51 @@
+//go:build go1.21
+
+package fxerror
+
+import (
+	"errors"
+	"log/slog"
+)
+
+// LogValue implements slog.LogValuer. It resolves to a group holding the error
+// message, the offending value and the category of the error.
+func (e *ValueError) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("msg", e.Error()),
+		slog.Any("value", e.Value),
+		slog.String("category", e.Code().String()),
+	)
+}
+
+// LogValue implements slog.LogValuer. It resolves to a group holding the error
+// message and its category.
+func (e *Error) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("msg", e.Error()),
+		slog.String("category", e.Code().String()),
+	)
+}
+
+// Attr returns an "error" attribute for err. When err wraps a ValueError the
+// attribute is a group exposing its value and category, when err belongs to a
+// category it is a group exposing it, otherwise it only holds the error message.
+func Attr(err error) slog.Attr {
+	if err == nil {
+		return slog.Any("error", nil)
+	}
+	var valueErr *ValueError
+	if errors.As(err, &valueErr) {
+		return slog.Group("error",
+			slog.String("msg", err.Error()),
+			slog.Any("value", valueErr.Value),
+			slog.String("category", valueErr.Code().String()),
+		)
+	}
+	if code := CodeOf(err); code != CodeUnknown {
+		return slog.Group("error",
+			slog.String("msg", err.Error()),
+			slog.String("category", code.String()),
+		)
+	}
+	return slog.String("error", err.Error())
+}
@@ -0,0 +1,37 @@
+package fxtypes
+
+import (
+	"fmt"
+	"io"
+
+	fxconv "github.com/fredsh/go-fxtend/pkg/internal/fx-conv"
+)
+
+// String implements fmt.Stringer, it prints Some(value) or None.
+func (o Option[T]) String() string {
+	return fmt.Sprint(o)
+}
+
+// Format implements fmt.Formatter. %v prints Some(value) or None, %+v adds the
+// type parameter and %#v prints the Go syntax building the Option. Other verbs
+// are applied to the inner value.
+func (o Option[T]) Format(f fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && f.Flag('#'):
+		if !o.isSet {
+			fmt.Fprintf(f, "fxtypes.NewNoneNone[%s]()", fxconv.TypeName[T]())
+			return
+		}
+		fmt.Fprintf(f, "fxtypes.Some[%s](%#v)", fxconv.TypeName[T](), o.value)
+	case verb == 'v' && f.Flag('+'):
+		if !o.isSet {
+			fmt.Fprintf(f, "None[%s]", fxconv.TypeName[T]())
+			return
+		}
+		fmt.Fprintf(f, "Some[%s](%+v)", fxconv.TypeName[T](), o.value)
+	case !o.isSet:
+		_, _ = io.WriteString(f, "None")
+	default:
+		fmt.Fprintf(f, "Some("+fxconv.Directive(f, verb)+")", o.value)
+	}
+}
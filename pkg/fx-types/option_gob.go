@@ -0,0 +1,52 @@
+package fxtypes
+
+import (
+	"bytes"
+	"encoding/gob"
+)
+
+// GobEncode implements gob.GobEncoder. Option fields are unexported so gob would
+// otherwise encode it as an empty struct.
+func (o Option[T]) GobEncode() ([]byte, error) {
+	var buf bytes.Buffer
+	enc := gob.NewEncoder(&buf)
+	if err := enc.Encode(o.isSet); err != nil {
+		return nil, err
+	}
+	if o.isSet {
+		if err := enc.Encode(&o.value); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode implements gob.GobDecoder.
+func (o *Option[T]) GobDecode(data []byte) error {
+	dec := gob.NewDecoder(bytes.NewReader(data))
+	var isSet bool
+	if err := dec.Decode(&isSet); err != nil {
+		return err
+	}
+	if !isSet {
+		*o = NewNoneNone[T]()
+		return nil
+	}
+
+	var value T
+	if err := dec.Decode(&value); err != nil {
+		return err
+	}
+	*o = NewValueOption(value)
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler using the gob encoding.
+func (o Option[T]) MarshalBinary() ([]byte, error) {
+	return o.GobEncode()
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler using the gob encoding.
+func (o *Option[T]) UnmarshalBinary(data []byte) error {
+	return o.GobDecode(data)
+}
@@ -0,0 +1,29 @@
+//go:build go1.21
+
+package fxtypes
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+// LogValue implements slog.LogValuer. An empty Option resolves to an empty group,
+// which handlers leave out of the record, a set one resolves to its value.
+func (o Option[T]) LogValue() slog.Value {
+	if !o.isSet {
+		return slog.GroupValue()
+	}
+	return slog.AnyValue(o.value)
+}
+
+// LogValue implements slog.LogValuer. A success resolves to its value, an error
+// result to a group holding the error message and the error type.
+func (r Result[T]) LogValue() slog.Value {
+	if r.err != nil {
+		return slog.GroupValue(
+			slog.String("error", r.err.Error()),
+			slog.String("type", fmt.Sprintf("%T", r.err)),
+		)
+	}
+	return slog.AnyValue(r.value)
+}
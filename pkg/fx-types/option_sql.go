@@ -0,0 +1,34 @@
+package fxtypes
+
+import (
+	"database/sql/driver"
+
+	fxconv "github.com/fredsh/go-fxtend/pkg/internal/fx-conv"
+)
+
+// Scan implements sql.Scanner. A NULL column produces an empty Option, any other
+// value is converted into T and stored as a set Option. If *T implements
+// sql.Scanner itself, the conversion is delegated to it.
+func (o *Option[T]) Scan(src any) error {
+	if src == nil {
+		*o = NewNoneNone[T]()
+		return nil
+	}
+
+	var value T
+	if err := fxconv.Scan(&value, src); err != nil {
+		return err
+	}
+	*o = NewValueOption(value)
+	return nil
+}
+
+// Value implements driver.Valuer. An empty Option is stored as NULL, a set one is
+// converted with the driver's default parameter converter, which honours a
+// driver.Valuer implemented by T.
+func (o Option[T]) Value() (driver.Value, error) {
+	if !o.isSet {
+		return nil, nil
+	}
+	return driver.DefaultParameterConverter.ConvertValue(o.value)
+}
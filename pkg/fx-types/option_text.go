@@ -0,0 +1,61 @@
+package fxtypes
+
+import (
+	"errors"
+
+	fxconv "github.com/fredsh/go-fxtend/pkg/internal/fx-conv"
+	"gopkg.in/yaml.v3"
+)
+
+// MarshalYAML implements yaml.Marshaler. An empty Option is encoded as null.
+func (o Option[T]) MarshalYAML() (interface{}, error) {
+	if !o.isSet {
+		return nil, nil
+	}
+	return o.value, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler. An explicit null produces an empty
+// Option, an absent key leaves the Option untouched.
+func (o *Option[T]) UnmarshalYAML(node *yaml.Node) error {
+	var value *T
+	if err := node.Decode(&value); err != nil {
+		return err
+	}
+	if value == nil {
+		*o = NewNoneNone[T]()
+		return nil
+	}
+	*o = NewValueOption(*value)
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler. An empty Option is encoded as an
+// empty text, so a value whose text is empty is rejected rather than read back as
+// an empty Option.
+func (o Option[T]) MarshalText() ([]byte, error) {
+	if !o.isSet {
+		return []byte{}, nil
+	}
+	text, err := fxconv.FormatText(o.value)
+	if err == nil && len(text) == 0 {
+		return nil, errors.New("cannot marshal an Option holding a value of an empty text, it would read back as an empty Option")
+	}
+	return text, err
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. An empty text produces an
+// empty Option.
+func (o *Option[T]) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*o = NewNoneNone[T]()
+		return nil
+	}
+
+	var value T
+	if err := fxconv.ParseText(&value, text); err != nil {
+		return err
+	}
+	*o = NewValueOption(value)
+	return nil
+}
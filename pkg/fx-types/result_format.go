@@ -0,0 +1,36 @@
+package fxtypes
+
+import (
+	"fmt"
+
+	fxconv "github.com/fredsh/go-fxtend/pkg/internal/fx-conv"
+)
+
+// String implements fmt.Stringer, it prints Ok(value) or Err(message).
+func (r Result[T]) String() string {
+	return fmt.Sprint(r)
+}
+
+// Format implements fmt.Formatter. %v prints Ok(value) or Err(message), %+v adds
+// the type parameter and the chain of wrapped errors and %#v prints the Go syntax
+// building the Result. Other verbs are applied to the success value.
+func (r Result[T]) Format(f fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && f.Flag('#'):
+		if r.err != nil {
+			fmt.Fprintf(f, "fxtypes.NewErrorResult[%s](%#v)", fxconv.TypeName[T](), r.err)
+			return
+		}
+		fmt.Fprintf(f, "fxtypes.NewSuccessResult[%s](%#v)", fxconv.TypeName[T](), r.value)
+	case verb == 'v' && f.Flag('+'):
+		if r.err != nil {
+			fmt.Fprintf(f, "Err[%s](%s)", fxconv.TypeName[T](), fxconv.ErrorChain(r.err))
+			return
+		}
+		fmt.Fprintf(f, "Ok[%s](%+v)", fxconv.TypeName[T](), r.value)
+	case r.err != nil:
+		fmt.Fprintf(f, "Err(%s)", r.err.Error())
+	default:
+		fmt.Fprintf(f, "Ok("+fxconv.Directive(f, verb)+")", r.value)
+	}
+}
@@ -0,0 +1,61 @@
+package fxtypes
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+)
+
+// GobEncode implements gob.GobEncoder. Only the message of a failure is encoded,
+// it is decoded back as a plain error so its type and wrapped errors are lost.
+func (r Result[T]) GobEncode() ([]byte, error) {
+	var buf bytes.Buffer
+	enc := gob.NewEncoder(&buf)
+	if err := enc.Encode(r.err != nil); err != nil {
+		return nil, err
+	}
+	if r.err != nil {
+		if err := enc.Encode(r.err.Error()); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+	if err := enc.Encode(&r.value); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode implements gob.GobDecoder.
+func (r *Result[T]) GobDecode(data []byte) error {
+	dec := gob.NewDecoder(bytes.NewReader(data))
+	var isError bool
+	if err := dec.Decode(&isError); err != nil {
+		return err
+	}
+	if isError {
+		var msg string
+		if err := dec.Decode(&msg); err != nil {
+			return err
+		}
+		*r = NewErrorResult[T](errors.New(msg))
+		return nil
+	}
+
+	var value T
+	if err := dec.Decode(&value); err != nil {
+		return err
+	}
+	*r = NewSuccessResult(value)
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler using the gob encoding.
+func (r Result[T]) MarshalBinary() ([]byte, error) {
+	return r.GobEncode()
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler using the gob encoding.
+func (r *Result[T]) UnmarshalBinary(data []byte) error {
+	return r.GobDecode(data)
+}
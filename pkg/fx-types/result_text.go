@@ -0,0 +1,10 @@
+package fxtypes
+
+// MarshalYAML implements yaml.Marshaler. A success is encoded as its value while
+// an error result makes the encoding fail with its error.
+func (r Result[T]) MarshalYAML() (interface{}, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	return r.value, nil
+}
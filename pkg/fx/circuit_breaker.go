@@ -0,0 +1,257 @@
+package fx
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	fxerror "github.com/fredsh/go-fxtend/pkg/fx-error"
+)
+
+// CircuitState is the state of a CircuitBreaker.
+type CircuitState int
+
+const (
+	// CircuitClosed lets every call through and counts failures.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen rejects every call until the cooldown has elapsed.
+	CircuitOpen
+	// CircuitHalfOpen lets a limited number of trial calls through to decide
+	// whether the circuit closes again.
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// CircuitBreakerConfig configures a CircuitBreaker.
+type CircuitBreakerConfig struct {
+	Name                string                                   // Reported in errors and state change hooks.
+	ConsecutiveFailures int                                      // Trip after this many consecutive failures, 0 disables it.
+	FailureRatio        float64                                  // Trip once failures/requests reaches it, 0 disables it.
+	MinRequests         int                                      // Requests needed before FailureRatio applies.
+	Interval            time.Duration                            // Period clearing the counts while closed, 0 never clears them.
+	Cooldown            time.Duration                            // Time spent open before trying again.
+	HalfOpenMaxCalls    int                                      // Trial calls allowed, and successes needed to close, while half-open.
+	IsFailure           func(error) bool                         // Defaults to any error but context.Canceled.
+	OnStateChange       func(name string, from, to CircuitState) // Called after each transition, outside of any lock.
+	Clock               Clock                                    // Defaults to SystemClock.
+}
+
+// DefaultCircuitBreakerConfig returns a configuration tripping after 5
+// consecutive failures and trying again after 30 seconds.
+func DefaultCircuitBreakerConfig() CircuitBreakerConfig {
+	return CircuitBreakerConfig{
+		ConsecutiveFailures: 5,
+		MinRequests:         10,
+		Cooldown:            30 * time.Second,
+		HalfOpenMaxCalls:    1,
+	}
+}
+
+type circuitCounts struct {
+	requests            int
+	failures            int
+	consecutiveFailures int
+	successes           int
+}
+
+type stateChange struct {
+	from CircuitState
+	to   CircuitState
+}
+
+// CircuitBreaker wraps Result returning calls and stops calling them for a
+// while once they fail too much. It is safe for concurrent use.
+type CircuitBreaker[T any] struct {
+	config CircuitBreakerConfig
+
+	mu          sync.Mutex
+	state       CircuitState
+	generation  uint64
+	counts      circuitCounts
+	windowStart time.Time
+	openedAt    time.Time
+}
+
+// NewCircuitBreaker creates a closed CircuitBreaker configured from
+// DefaultCircuitBreakerConfig and the given enhancers.
+func NewCircuitBreaker[T any](opts ...OptionEnhancer[CircuitBreakerConfig]) *CircuitBreaker[T] {
+	config := OptionBuilder(DefaultCircuitBreakerConfig, opts...)
+	if config.Clock == nil {
+		config.Clock = SystemClock()
+	}
+	if config.IsFailure == nil {
+		config.IsFailure = func(err error) bool {
+			return err != nil && !errors.Is(err, context.Canceled)
+		}
+	}
+	if config.HalfOpenMaxCalls < 1 {
+		config.HalfOpenMaxCalls = 1
+	}
+	return &CircuitBreaker[T]{
+		config:      config,
+		windowStart: config.Clock.Now(),
+	}
+}
+
+// Call calls fn if the circuit lets it through and records its outcome. When the
+// circuit is open, it returns a failure matching fxerror.ErrCircuitOpen without
+// calling fn. Like FlatMapCtx, a cancelled context produces a failure holding
+// ctx.Err().
+func (cb *CircuitBreaker[T]) Call(ctx context.Context, fn func(context.Context) Result[T]) Result[T] {
+	if ctx.Err() != nil {
+		return NewFailure[T](ctx.Err())
+	}
+	generation, err := cb.before()
+	if err != nil {
+		return NewFailure[T](err)
+	}
+
+	completed := false
+	defer func() {
+		if !completed {
+			// fn panicked, count it as a failure before propagating the panic
+			cb.after(generation, true)
+		}
+	}()
+	res := fn(ctx)
+	completed = true
+	cb.after(generation, cb.config.IsFailure(res.AsError()))
+	return res
+}
+
+// State returns the current state of the circuit.
+func (cb *CircuitBreaker[T]) State() CircuitState {
+	var changes []stateChange
+	cb.mu.Lock()
+	state := cb.currentState(cb.config.Clock.Now(), &changes)
+	cb.mu.Unlock()
+	cb.notify(changes)
+	return state
+}
+
+// Reset closes the circuit and clears its counts.
+func (cb *CircuitBreaker[T]) Reset() {
+	var changes []stateChange
+	cb.mu.Lock()
+	now := cb.config.Clock.Now()
+	cb.setState(CircuitClosed, now, &changes)
+	cb.generation++
+	cb.counts = circuitCounts{}
+	cb.windowStart = now
+	cb.mu.Unlock()
+	cb.notify(changes)
+}
+
+func (cb *CircuitBreaker[T]) before() (uint64, error) {
+	var changes []stateChange
+	cb.mu.Lock()
+	state := cb.currentState(cb.config.Clock.Now(), &changes)
+	var err error
+	if state == CircuitOpen || (state == CircuitHalfOpen && cb.counts.requests >= cb.config.HalfOpenMaxCalls) {
+		err = fxerror.NewValueError(fxerror.ErrCircuitOpen, cb.config.Name)
+	} else {
+		cb.counts.requests++
+	}
+	generation := cb.generation
+	cb.mu.Unlock()
+	cb.notify(changes)
+	return generation, err
+}
+
+func (cb *CircuitBreaker[T]) after(generation uint64, failed bool) {
+	var changes []stateChange
+	cb.mu.Lock()
+	now := cb.config.Clock.Now()
+	state := cb.currentState(now, &changes)
+	// outcomes of calls started before a transition do not count
+	if generation == cb.generation {
+		if failed {
+			cb.onFailure(state, now, &changes)
+		} else {
+			cb.onSuccess(state, now, &changes)
+		}
+	}
+	cb.mu.Unlock()
+	cb.notify(changes)
+}
+
+func (cb *CircuitBreaker[T]) onSuccess(state CircuitState, now time.Time, changes *[]stateChange) {
+	cb.counts.successes++
+	cb.counts.consecutiveFailures = 0
+	if state == CircuitHalfOpen && cb.counts.successes >= cb.config.HalfOpenMaxCalls {
+		cb.setState(CircuitClosed, now, changes)
+	}
+}
+
+func (cb *CircuitBreaker[T]) onFailure(state CircuitState, now time.Time, changes *[]stateChange) {
+	cb.counts.failures++
+	cb.counts.consecutiveFailures++
+	if state == CircuitHalfOpen || cb.shouldTrip() {
+		cb.setState(CircuitOpen, now, changes)
+	}
+}
+
+func (cb *CircuitBreaker[T]) shouldTrip() bool {
+	c := cb.counts
+	if cb.config.ConsecutiveFailures > 0 && c.consecutiveFailures >= cb.config.ConsecutiveFailures {
+		return true
+	}
+	if cb.config.FailureRatio > 0 && c.requests > 0 && c.requests >= cb.config.MinRequests {
+		return float64(c.failures)/float64(c.requests) >= cb.config.FailureRatio
+	}
+	return false
+}
+
+// currentState applies the transitions due to the passing of time and returns
+// the resulting state, it must be called with mu held.
+func (cb *CircuitBreaker[T]) currentState(now time.Time, changes *[]stateChange) CircuitState {
+	switch cb.state {
+	case CircuitClosed:
+		if cb.config.Interval > 0 && now.Sub(cb.windowStart) >= cb.config.Interval {
+			cb.generation++
+			cb.counts = circuitCounts{}
+			cb.windowStart = now
+		}
+	case CircuitOpen:
+		if now.Sub(cb.openedAt) >= cb.config.Cooldown {
+			cb.setState(CircuitHalfOpen, now, changes)
+		}
+	}
+	return cb.state
+}
+
+// setState must be called with mu held.
+func (cb *CircuitBreaker[T]) setState(to CircuitState, now time.Time, changes *[]stateChange) {
+	if cb.state == to {
+		return
+	}
+	*changes = append(*changes, stateChange{from: cb.state, to: to})
+	cb.state = to
+	cb.generation++
+	cb.counts = circuitCounts{}
+	cb.windowStart = now
+	if to == CircuitOpen {
+		cb.openedAt = now
+	}
+}
+
+func (cb *CircuitBreaker[T]) notify(changes []stateChange) {
+	if cb.config.OnStateChange == nil {
+		return
+	}
+	for _, c := range changes {
+		cb.config.OnStateChange(cb.config.Name, c.from, c.to)
+	}
+}
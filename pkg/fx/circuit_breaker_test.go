@@ -0,0 +1,191 @@
+package fx
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	fxerror "github.com/fredsh/go-fxtend/pkg/fx-error"
+	"github.com/stretchr/testify/require"
+)
+
+type transition struct {
+	from, to CircuitState
+}
+
+func newTestBreaker(clock Clock, transitions *[]transition, opts ...OptionEnhancer[CircuitBreakerConfig]) *CircuitBreaker[int] {
+	opts = append([]OptionEnhancer[CircuitBreakerConfig]{func(c CircuitBreakerConfig) CircuitBreakerConfig {
+		c.Name = "test"
+		c.ConsecutiveFailures = 3
+		c.Cooldown = time.Minute
+		c.Clock = clock
+		c.OnStateChange = func(name string, from, to CircuitState) {
+			*transitions = append(*transitions, transition{from, to})
+		}
+		return c
+	}}, opts...)
+	return NewCircuitBreaker[int](opts...)
+}
+
+func succeed(context.Context) Result[int] { return NewSuccess(1) }
+func fail(context.Context) Result[int]    { return NewFailure[int](errors.New("boom")) }
+
+func TestCircuitBreakerConsecutiveFailures(t *testing.T) {
+	clock := newFakeClock()
+	var transitions []transition
+	cb := newTestBreaker(clock, &transitions)
+	ctx := context.Background()
+
+	cb.Call(ctx, fail)
+	cb.Call(ctx, fail)
+	cb.Call(ctx, succeed)
+	cb.Call(ctx, fail)
+	cb.Call(ctx, fail)
+	require.Equal(t, CircuitClosed, cb.State(), "a success resets consecutive failures")
+
+	cb.Call(ctx, fail)
+	require.Equal(t, CircuitOpen, cb.State())
+
+	called := false
+	res := cb.Call(ctx, func(context.Context) Result[int] {
+		called = true
+		return NewSuccess(1)
+	})
+	require.False(t, called)
+	require.True(t, fxerror.IsCircuitOpen(res.AsError()))
+	require.True(t, fxerror.IsUnavailable(res.AsError()))
+	require.EqualError(t, res.AsError(), "circuit breaker is open: [test]")
+
+	clock.Advance(time.Minute)
+	require.Equal(t, CircuitHalfOpen, cb.State())
+	cb.Call(ctx, fail)
+	require.Equal(t, CircuitOpen, cb.State(), "a failed trial call reopens the circuit")
+
+	clock.Advance(time.Minute)
+	require.Equal(t, 1, cb.Call(ctx, succeed).Unwrap())
+	require.Equal(t, CircuitClosed, cb.State())
+
+	require.Equal(t, []transition{
+		{CircuitClosed, CircuitOpen},
+		{CircuitOpen, CircuitHalfOpen},
+		{CircuitHalfOpen, CircuitOpen},
+		{CircuitOpen, CircuitHalfOpen},
+		{CircuitHalfOpen, CircuitClosed},
+	}, transitions)
+}
+
+func TestCircuitBreakerFailureRatio(t *testing.T) {
+	clock := newFakeClock()
+	var transitions []transition
+	cb := newTestBreaker(clock, &transitions, func(c CircuitBreakerConfig) CircuitBreakerConfig {
+		c.ConsecutiveFailures = 0
+		c.FailureRatio = 0.5
+		c.MinRequests = 4
+		c.Interval = time.Minute
+		return c
+	})
+	ctx := context.Background()
+
+	cb.Call(ctx, fail)
+	cb.Call(ctx, fail)
+	cb.Call(ctx, succeed)
+	require.Equal(t, CircuitClosed, cb.State(), "ratio does not apply below the minimum requests")
+
+	clock.Advance(time.Minute)
+	cb.Call(ctx, succeed)
+	cb.Call(ctx, succeed)
+	cb.Call(ctx, fail)
+	cb.Call(ctx, succeed)
+	require.Equal(t, CircuitClosed, cb.State(), "counts are cleared after the interval")
+
+	cb.Call(ctx, fail)
+	require.Equal(t, CircuitClosed, cb.State())
+	cb.Call(ctx, fail)
+	require.Equal(t, CircuitOpen, cb.State())
+}
+
+func TestCircuitBreakerHalfOpenLimit(t *testing.T) {
+	clock := newFakeClock()
+	var transitions []transition
+	cb := newTestBreaker(clock, &transitions, func(c CircuitBreakerConfig) CircuitBreakerConfig {
+		c.ConsecutiveFailures = 1
+		c.HalfOpenMaxCalls = 2
+		return c
+	})
+	ctx := context.Background()
+
+	cb.Call(ctx, fail)
+	clock.Advance(time.Minute)
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	done := make(chan Result[int])
+	go func() {
+		done <- cb.Call(ctx, func(context.Context) Result[int] {
+			close(started)
+			<-release
+			return NewSuccess(1)
+		})
+	}()
+	<-started
+	require.Equal(t, 1, cb.Call(ctx, succeed).Unwrap())
+	require.True(t, fxerror.IsCircuitOpen(cb.Call(ctx, succeed).AsError()), "trial calls are limited")
+	require.Equal(t, CircuitHalfOpen, cb.State())
+
+	close(release)
+	require.True(t, (<-done).IsSuccess())
+	require.Equal(t, CircuitClosed, cb.State())
+}
+
+func TestCircuitBreakerIgnoresCancellation(t *testing.T) {
+	clock := newFakeClock()
+	var transitions []transition
+	cb := newTestBreaker(clock, &transitions, func(c CircuitBreakerConfig) CircuitBreakerConfig {
+		c.ConsecutiveFailures = 1
+		return c
+	})
+
+	res := cb.Call(context.Background(), func(context.Context) Result[int] {
+		return NewFailure[int](context.Canceled)
+	})
+	require.ErrorIs(t, res.AsError(), context.Canceled)
+	require.Equal(t, CircuitClosed, cb.State())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(t, cb.Call(ctx, succeed).AsError(), context.Canceled)
+
+	require.Panics(t, func() {
+		cb.Call(context.Background(), func(context.Context) Result[int] { panic("boom") })
+	})
+	require.Equal(t, CircuitOpen, cb.State(), "a panic counts as a failure")
+
+	cb.Reset()
+	require.Equal(t, CircuitClosed, cb.State())
+}
+
+func TestCircuitBreakerConcurrentUse(t *testing.T) {
+	cb := NewCircuitBreaker[int](func(c CircuitBreakerConfig) CircuitBreakerConfig {
+		c.Clock = newFakeClock()
+		c.ConsecutiveFailures = 0
+		return c
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if i%2 == 0 {
+				cb.Call(context.Background(), fail)
+			} else {
+				cb.Call(context.Background(), succeed)
+			}
+			cb.State()
+		}(i)
+	}
+	wg.Wait()
+	require.Equal(t, CircuitClosed, cb.State())
+}
@@ -0,0 +1,62 @@
+package fx
+
+import (
+	"context"
+	"time"
+)
+
+// Clock abstracts the passing of time so that time dependent helpers can be
+// tested without sleeping.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// StoppableClock is a Clock whose timers can be stopped before they fire, to
+// release them when a wait is abandoned.
+type StoppableClock interface {
+	Clock
+	// NewTimer returns a channel receiving the time after d and a function
+	// stopping the timer.
+	NewTimer(d time.Duration) (<-chan time.Time, func() bool)
+}
+
+type systemClock struct{}
+
+// SystemClock returns the Clock backed by the time package.
+func SystemClock() Clock {
+	return systemClock{}
+}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+func (systemClock) NewTimer(d time.Duration) (<-chan time.Time, func() bool) {
+	timer := time.NewTimer(d)
+	return timer.C, timer.Stop
+}
+
+// sleepCtx waits for d on clock or until ctx is done, in which case it returns
+// ctx.Err(). The timer is stopped early when clock is a StoppableClock, other
+// clocks keep it until it fires.
+func sleepCtx(ctx context.Context, clock Clock, d time.Duration) error {
+	var fired <-chan time.Time
+	if stoppable, ok := clock.(StoppableClock); ok {
+		var stop func() bool
+		fired, stop = stoppable.NewTimer(d)
+		defer stop()
+	} else {
+		fired = clock.After(d)
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-fired:
+		return nil
+	}
+}
@@ -0,0 +1,308 @@
+package fx
+
+import (
+	"encoding"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	fxconv "github.com/fredsh/go-fxtend/pkg/internal/fx-conv"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultSourceName is reported for the fields no ConfigSource supplied.
+const DefaultSourceName = "default"
+
+// ConfigSource loads part of a config of type T. Load updates config in place
+// and returns the path of the fields it supplied, such as "Port" or
+// "Server.Port". Load receives a deep copy of the config, see WithSource, which
+// the builder discards on error.
+//
+// Sources are applied in order so that later ones take precedence, the usual
+// order being JSONFileSource, YAMLFileSource, EnvSource then FlagSource, from the
+// least to the most specific.
+type ConfigSource[T any] struct {
+	Name string
+	Load func(config *T) ([]string, error)
+}
+
+// EnvSource reads the fields tagged `env:"NAME"` from the environment variable
+// prefix+NAME. Values are parsed as text, see encoding.TextUnmarshaler, and
+// untagged struct fields are searched for tagged fields too.
+func EnvSource[T any](prefix string) ConfigSource[T] {
+	return ConfigSource[T]{
+		Name: "env",
+		Load: func(config *T) ([]string, error) {
+			var supplied []string
+			for _, field := range taggedFields(reflect.ValueOf(config).Elem(), "env", "") {
+				name := prefix + field.key
+				text, ok := os.LookupEnv(name)
+				if !ok {
+					continue
+				}
+				if err := fxconv.ParseText(field.value.Addr().Interface(), []byte(text)); err != nil {
+					return nil, fmt.Errorf("parsing %s: %w", name, err)
+				}
+				supplied = append(supplied, field.path)
+			}
+			return supplied, nil
+		},
+	}
+}
+
+// FlagSource reads the fields tagged `flag:"name"` from the flags of fs which
+// were set on the command line, fs must already be parsed. The flags can be
+// defined by hand or with DefineFlags.
+func FlagSource[T any](fs *flag.FlagSet) ConfigSource[T] {
+	return ConfigSource[T]{
+		Name: "flag",
+		Load: func(config *T) ([]string, error) {
+			set := map[string]flag.Value{}
+			fs.Visit(func(f *flag.Flag) { set[f.Name] = f.Value })
+
+			var supplied []string
+			for _, field := range taggedFields(reflect.ValueOf(config).Elem(), "flag", "") {
+				value, ok := set[field.key]
+				if !ok {
+					continue
+				}
+				if err := fxconv.ParseText(field.value.Addr().Interface(), []byte(value.String())); err != nil {
+					return nil, fmt.Errorf("parsing -%s: %w", field.key, err)
+				}
+				supplied = append(supplied, field.path)
+			}
+			return supplied, nil
+		},
+	}
+}
+
+// DefineFlags defines a flag on fs for every field of T tagged `flag:"name"`,
+// using the value of the field in defaults as default and the `usage` tag as
+// usage.
+func DefineFlags[T any](fs *flag.FlagSet, defaults T) error {
+	for _, field := range taggedFields(reflect.ValueOf(&defaults).Elem(), "flag", "") {
+		text, err := fxconv.FormatText(field.value.Interface())
+		if err != nil {
+			return fmt.Errorf("formatting default of -%s: %w", field.key, err)
+		}
+		isBool := field.value.Kind() == reflect.Bool
+		fs.Var(&textFlag{text: string(text), isBool: isBool}, field.key, field.usage)
+	}
+	return nil
+}
+
+// textFlag is a flag.Value keeping the raw text, parsed later by FlagSource.
+type textFlag struct {
+	text   string
+	isBool bool
+}
+
+func (f *textFlag) String() string {
+	return f.text
+}
+
+func (f *textFlag) Set(text string) error {
+	f.text = text
+	return nil
+}
+
+// IsBoolFlag allows boolean fields to be set with -name alone.
+func (f *textFlag) IsBoolFlag() bool {
+	return f.isBool
+}
+
+// JSONFileSource decodes the JSON file at path over the config. It reports the
+// fields present in the file, with the path of the nested ones such as
+// "Limits.Burst" like EnvSource and FlagSource.
+func JSONFileSource[T any](path string) ConfigSource[T] {
+	return ConfigSource[T]{
+		Name: "json:" + path,
+		Load: func(config *T) ([]string, error) {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return nil, err
+			}
+			if err := json.Unmarshal(data, config); err != nil {
+				return nil, fmt.Errorf("decoding %s: %w", path, err)
+			}
+			supplied, err := fileFields(reflect.TypeOf(config).Elem(), "json", data, json.Unmarshal, strings.EqualFold)
+			if err != nil {
+				return nil, fmt.Errorf("decoding %s: %w", path, err)
+			}
+			return supplied, nil
+		},
+	}
+}
+
+// YAMLFileSource decodes the YAML file at path over the config. It reports the
+// fields present in the file like JSONFileSource.
+func YAMLFileSource[T any](path string) ConfigSource[T] {
+	return ConfigSource[T]{
+		Name: "yaml:" + path,
+		Load: func(config *T) ([]string, error) {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return nil, err
+			}
+			if err := yaml.Unmarshal(data, config); err != nil {
+				return nil, fmt.Errorf("decoding %s: %w", path, err)
+			}
+			supplied, err := fileFields(reflect.TypeOf(config).Elem(), "yaml", data, yaml.Unmarshal, func(a, b string) bool { return a == b })
+			if err != nil {
+				return nil, fmt.Errorf("decoding %s: %w", path, err)
+			}
+			return supplied, nil
+		},
+	}
+}
+
+// WithSource applies a ConfigSource and records the fields it supplied. Like
+// WithE, its error is kept for Validate and the config is left unchanged: the
+// source loads a deep copy of the config, so a failure midway does not leak
+// through maps or slices shared with it.
+func (b *OptionBuilderFluent[T]) WithSource(source ConfigSource[T]) *OptionBuilderFluent[T] {
+	if b.tracing {
+		defer b.trace(source.Name, b.clone(), len(b.errs))
+	}
+	config := b.clone()
+	supplied, err := source.Load(&config)
+	if err != nil {
+		b.errs = append(b.errs, fmt.Errorf("source %s: %w", source.Name, err))
+		return b
+	}
+	b.options = config
+	if b.sources == nil {
+		b.sources = map[string]string{}
+	}
+	for _, path := range supplied {
+		b.sources[path] = source.Name
+	}
+	return b
+}
+
+// WithSources applies every source in order, see WithSource. The last source
+// supplying a field wins, both for its value and for SourceOf.
+func (b *OptionBuilderFluent[T]) WithSources(sources ...ConfigSource[T]) *OptionBuilderFluent[T] {
+	for _, source := range sources {
+		b.WithSource(source)
+	}
+	return b
+}
+
+// Sources returns the name of the source which last supplied each field.
+func (b *OptionBuilderFluent[T]) Sources() map[string]string {
+	sources := make(map[string]string, len(b.sources))
+	for path, name := range b.sources {
+		sources[path] = name
+	}
+	return sources
+}
+
+// SourceOf returns the name of the source which last supplied the field at path,
+// or DefaultSourceName if none did.
+func (b *OptionBuilderFluent[T]) SourceOf(path string) string {
+	if name, ok := b.sources[path]; ok {
+		return name
+	}
+	return DefaultSourceName
+}
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+type configField struct {
+	path  string
+	key   string
+	usage string
+	value reflect.Value
+}
+
+// taggedFields lists the exported fields of the struct v tagged with tag,
+// looking into untagged struct fields which are not text values themselves.
+func taggedFields(v reflect.Value, tag string, prefix string) []configField {
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	var fields []configField
+	vt := v.Type()
+	for i := 0; i < vt.NumField(); i++ {
+		sf := vt.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		key := sf.Tag.Get(tag)
+		if key == "-" {
+			continue
+		}
+		if key != "" {
+			fields = append(fields, configField{path: prefix + sf.Name, key: key, usage: sf.Tag.Get("usage"), value: v.Field(i)})
+			continue
+		}
+		if sf.Type.Kind() == reflect.Struct && !reflect.PointerTo(sf.Type).Implements(textUnmarshalerType) {
+			fields = append(fields, taggedFields(v.Field(i), tag, prefix+sf.Name+".")...)
+		}
+	}
+	return fields
+}
+
+// fileFields decodes the top-level object of data with unmarshal and returns the
+// paths of the fields of t it holds, see presentFields. Configs which are
+// neither structs nor maps do not report any field.
+func fileFields(t reflect.Type, tag string, data []byte, unmarshal func([]byte, any) error, match func(string, string) bool) ([]string, error) {
+	if t.Kind() != reflect.Struct && t.Kind() != reflect.Map {
+		return nil, nil
+	}
+	var keys map[string]any
+	if err := unmarshal(data, &keys); err != nil {
+		return nil, err
+	}
+	return presentFields(t, tag, keys, match, ""), nil
+}
+
+// presentFields returns the paths of the fields of t whose encoded key, taken
+// from tag or defaulting to the field name, is one of keys. Struct fields given
+// as an object are walked to report their nested fields instead. For any other
+// type, such as a map, the keys themselves are reported in order.
+func presentFields(t reflect.Type, tag string, keys map[string]any, match func(string, string) bool, prefix string) []string {
+	if t.Kind() != reflect.Struct {
+		present := make([]string, 0, len(keys))
+		for key := range keys {
+			present = append(present, prefix+key)
+		}
+		sort.Strings(present)
+		return present
+	}
+	var present []string
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(sf.Tag.Get(tag), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+			if tag == "yaml" {
+				name = strings.ToLower(name)
+			}
+		}
+		for key, value := range keys {
+			if !match(key, name) {
+				continue
+			}
+			nested, isObject := value.(map[string]any)
+			if isObject && sf.Type.Kind() == reflect.Struct && !reflect.PointerTo(sf.Type).Implements(textUnmarshalerType) {
+				present = append(present, presentFields(sf.Type, tag, nested, match, prefix+sf.Name+".")...)
+			} else {
+				present = append(present, prefix+sf.Name)
+			}
+			break
+		}
+	}
+	return present
+}
@@ -0,0 +1,161 @@
+package fx
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type limitsConfig struct {
+	Burst int `env:"BURST" flag:"burst"`
+}
+
+type appConfig struct {
+	Name    string               `json:"name" yaml:"name" env:"NAME" flag:"name" usage:"application name"`
+	Port    int                  `json:"port" yaml:"port" env:"PORT" flag:"port"`
+	Timeout Maybe[time.Duration] `json:"timeout" yaml:"timeout" env:"TIMEOUT"`
+	Debug   bool                 `json:"debug" yaml:"debug" flag:"debug"`
+	Limits  limitsConfig         `json:"limits" yaml:"limits"`
+	Labels  map[string]string    `json:"labels" yaml:"labels"`
+	secret  string
+}
+
+func writeFile(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestConfigSources(t *testing.T) {
+	jsonPath := writeFile(t, "app.json", `{"name": "from-json", "port": 1000, "limits": {"burst": 5}}`)
+	yamlPath := writeFile(t, "app.yaml", "port: 2000\ntimeout: 3s\n")
+	t.Setenv("APP_PORT", "3000")
+	t.Setenv("APP_BURST", "7")
+
+	defaults := appConfig{Name: "default", Port: 80}
+	fs := flag.NewFlagSet("app", flag.ContinueOnError)
+	require.NoError(t, DefineFlags(fs, defaults))
+	require.Equal(t, "application name", fs.Lookup("name").Usage)
+	require.Equal(t, "80", fs.Lookup("port").DefValue)
+	require.NoError(t, fs.Parse([]string{"-debug", "-burst", "9"}))
+
+	b := NewConfigBuilderFluent(defaults)
+	res := b.WithSources(
+		JSONFileSource[appConfig](jsonPath),
+		YAMLFileSource[appConfig](yamlPath),
+		EnvSource[appConfig]("APP_"),
+		FlagSource[appConfig](fs),
+	).Validate(nil)
+
+	require.NoError(t, res.AsError())
+	require.Equal(t, appConfig{
+		Name:    "from-json",
+		Port:    3000,
+		Timeout: NewSome(3 * time.Second),
+		Debug:   true,
+		Limits:  limitsConfig{Burst: 9},
+	}, res.Unwrap())
+	require.Equal(t, map[string]string{
+		"Name":         "json:" + jsonPath,
+		"Port":         "env",
+		"Timeout":      "yaml:" + yamlPath,
+		"Limits.Burst": "flag",
+		"Debug":        "flag",
+	}, b.Sources())
+	require.Equal(t, "env", b.SourceOf("Port"))
+	require.Equal(t, DefaultSourceName, b.SourceOf("secret"))
+}
+
+func TestConfigSourceErrors(t *testing.T) {
+	t.Setenv("BAD_PORT", "eighty")
+	badJSON := writeFile(t, "bad.json", `{"port": "eighty"}`)
+
+	cases := []struct {
+		name   string
+		source ConfigSource[appConfig]
+	}{
+		{name: "missing file", source: JSONFileSource[appConfig](filepath.Join(t.TempDir(), "missing.json"))},
+		{name: "invalid JSON value", source: JSONFileSource[appConfig](badJSON)},
+		{name: "invalid JSON after a map", source: JSONFileSource[appConfig](writeFile(t, "partial.json", `{"port": "eighty", "labels": {"b": "2"}}`))},
+		{name: "invalid YAML", source: YAMLFileSource[appConfig](writeFile(t, "bad.yaml", "port: [1"))},
+		{name: "invalid env value", source: EnvSource[appConfig]("BAD_")},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			defaults := appConfig{Port: 80, Labels: map[string]string{"a": "1"}}
+			b := NewConfigBuilderFluent(defaults)
+			res := b.WithSource(tt.source).Validate(nil)
+			require.ErrorContains(t, res.AsError(), tt.source.Name)
+			require.Equal(t, appConfig{Port: 80, Labels: map[string]string{"a": "1"}}, b.Config(), "a failing source leaves the config unchanged")
+			require.Equal(t, map[string]string{"a": "1"}, defaults.Labels, "a failing source does not write through shared maps")
+			require.Empty(t, b.Sources())
+		})
+	}
+}
+
+func TestConfigSourcesNested(t *testing.T) {
+	cases := []struct {
+		name   string
+		source ConfigSource[appConfig]
+	}{
+		{name: "JSON", source: JSONFileSource[appConfig](writeFile(t, "app.json", `{"port": 1, "limits": {"burst": 5}}`))},
+		{name: "YAML", source: YAMLFileSource[appConfig](writeFile(t, "app.yaml", "port: 1\nlimits:\n  burst: 5\n"))},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewConfigBuilderFluent(appConfig{})
+			require.NoError(t, b.WithSource(tt.source).Validate(nil).AsError())
+			require.Equal(t, 5, b.Config().Limits.Burst)
+			require.Equal(t, map[string]string{"Port": tt.source.Name, "Limits.Burst": tt.source.Name}, b.Sources(),
+				"nested fields are reported with the same paths as EnvSource and FlagSource")
+		})
+	}
+}
+
+func TestConfigSourcesLastWins(t *testing.T) {
+	first := JSONFileSource[appConfig](writeFile(t, "first.json", `{"name": "first", "port": 1}`))
+	second := JSONFileSource[appConfig](writeFile(t, "second.json", `{"port": 2}`))
+	t.Setenv("LAST_PORT", "3")
+
+	cases := []struct {
+		name       string
+		sources    []ConfigSource[appConfig]
+		wantPort   int
+		wantSource string
+	}{
+		{name: "later file wins", sources: []ConfigSource[appConfig]{first, second}, wantPort: 2, wantSource: second.Name},
+		{name: "order decides, not the kind of source", sources: []ConfigSource[appConfig]{EnvSource[appConfig]("LAST_"), first}, wantPort: 1, wantSource: first.Name},
+		{name: "env after files wins", sources: []ConfigSource[appConfig]{first, second, EnvSource[appConfig]("LAST_")}, wantPort: 3, wantSource: "env"},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewConfigBuilderFluent(appConfig{})
+			require.NoError(t, b.WithSources(tt.sources...).Validate(nil).AsError())
+			require.Equal(t, tt.wantPort, b.Config().Port)
+			require.Equal(t, tt.wantSource, b.SourceOf("Port"))
+			require.Equal(t, "first", b.Config().Name, "fields a later source does not supply are kept")
+			require.Equal(t, first.Name, b.SourceOf("Name"))
+		})
+	}
+}
+
+func TestConfigSourcesNonStruct(t *testing.T) {
+	jsonPath := writeFile(t, "labels.json", `{"team": "core", "env": "prod"}`)
+	yamlPath := writeFile(t, "labels.yaml", "env: dev\n")
+
+	labels := NewConfigBuilderFluent(map[string]string{})
+	labels.WithSources(JSONFileSource[map[string]string](jsonPath), YAMLFileSource[map[string]string](yamlPath))
+	require.NoError(t, labels.Validate(nil).AsError())
+	require.Equal(t, map[string]string{"team": "core", "env": "dev"}, labels.Config())
+	require.Equal(t, map[string]string{"team": "json:" + jsonPath, "env": "yaml:" + yamlPath}, labels.Sources())
+
+	list := NewConfigBuilderFluent([]int{})
+	list.WithSource(JSONFileSource[[]int](writeFile(t, "list.json", `[1, 2]`)))
+	require.NoError(t, list.Validate(nil).AsError())
+	require.Equal(t, []int{1, 2}, list.Config())
+	require.Empty(t, list.Sources(), "configs which are neither structs nor maps report no field")
+}
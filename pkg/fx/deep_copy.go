@@ -0,0 +1,53 @@
+package fx
+
+import "reflect"
+
+// deepCopy returns a copy of v whose maps and slices, as well as those of its
+// exported struct fields and of their elements, are copied too. Pointers,
+// interfaces, channels and unexported fields are shared with v.
+func deepCopy[T any](v T) T {
+	rv := reflect.ValueOf(&v).Elem()
+	rv.Set(copyValue(rv))
+	return v
+}
+
+func copyValue(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			out.SetMapIndex(iter.Key(), copyValue(iter.Value()))
+		}
+		return out
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(copyValue(v.Index(i)))
+		}
+		return out
+	case reflect.Array:
+		out := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(copyValue(v.Index(i)))
+		}
+		return out
+	case reflect.Struct:
+		out := reflect.New(v.Type()).Elem()
+		out.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if field := out.Field(i); field.CanSet() {
+				field.Set(copyValue(v.Field(i)))
+			}
+		}
+		return out
+	default:
+		return v
+	}
+}
@@ -0,0 +1,49 @@
+package fx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeepCopy(t *testing.T) {
+	type inner struct {
+		Tags []string
+	}
+	type config struct {
+		Labels  map[string][]int
+		Inners  []inner
+		Fixed   [1]map[string]int
+		Nil     map[string]int
+		Pointer *inner
+		private map[string]int
+	}
+	original := config{
+		Labels:  map[string][]int{"a": {1}},
+		Inners:  []inner{{Tags: []string{"x"}}},
+		Fixed:   [1]map[string]int{{"f": 1}},
+		Pointer: &inner{},
+		private: map[string]int{"p": 1},
+	}
+
+	copied := deepCopy(original)
+	require.Equal(t, original, copied)
+
+	copied.Labels["a"][0] = 2
+	copied.Labels["b"] = nil
+	copied.Inners[0].Tags[0] = "y"
+	copied.Fixed[0]["f"] = 2
+	require.Equal(t, map[string][]int{"a": {1}}, original.Labels)
+	require.Equal(t, []inner{{Tags: []string{"x"}}}, original.Inners)
+	require.Equal(t, 1, original.Fixed[0]["f"])
+	require.Nil(t, copied.Nil)
+
+	require.Same(t, original.Pointer, copied.Pointer, "pointers are shared")
+	copied.private["p"] = 2
+	require.Equal(t, 2, original.private["p"], "unexported fields are shared")
+
+	require.Equal(t, 3, deepCopy(3))
+	require.Nil(t, deepCopy[any](nil), "nil interfaces are copied as is")
+	require.Nil(t, deepCopy[error](nil))
+	require.Equal(t, []any{nil}, deepCopy([]any{nil}))
+}
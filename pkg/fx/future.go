@@ -0,0 +1,215 @@
+package fx
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	fxerror "github.com/fredsh/go-fxtend/pkg/fx-error"
+)
+
+type futureState[T any] struct {
+	parent context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+	res    Result[T]
+}
+
+// Future is the Result of an asynchronous computation. Copies of a Future share
+// the same computation. The zero value is a Future already failed with an
+// fxerror.ErrInvalidArgument error.
+type Future[T any] struct {
+	state *futureState[T]
+}
+
+// get returns the state of f, a failed one for the zero value.
+func (f Future[T]) get() *futureState[T] {
+	if f.state == nil {
+		return Resolved(NewFailure[T](fxerror.New(fxerror.ErrInvalidArgument, "zero value Future"))).state
+	}
+	return f.state
+}
+
+// Async starts fn in a new goroutine and returns its Future. fn receives a
+// context derived from ctx, cancelled when ctx is or when the Future is
+// cancelled. Like FlatMapCtx, fn is not called if ctx is already done and the
+// Future then holds ctx.Err(). A panic in fn produces a failure.
+func Async[T any](ctx context.Context, fn func(context.Context) Result[T]) Future[T] {
+	fnCtx, cancel := context.WithCancel(ctx)
+	state := &futureState[T]{
+		parent: ctx,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	go func() {
+		defer close(state.done)
+		defer cancel()
+		defer func() {
+			if r := recover(); r != nil {
+				state.res = NewFailure[T](fmt.Errorf("async computation panicked: %v", r))
+			}
+		}()
+		if fnCtx.Err() != nil {
+			state.res = NewFailure[T](fnCtx.Err())
+			return
+		}
+		state.res = fn(fnCtx)
+	}()
+	return Future[T]{state: state}
+}
+
+// Resolved returns a Future already holding res.
+func Resolved[T any](res Result[T]) Future[T] {
+	state := &futureState[T]{
+		parent: context.Background(),
+		cancel: func() {},
+		done:   make(chan struct{}),
+		res:    res,
+	}
+	close(state.done)
+	return Future[T]{state: state}
+}
+
+// Await waits for the Future to complete and returns its Result, or a failure
+// holding ctx.Err() if ctx is done first.
+func (f Future[T]) Await(ctx context.Context) Result[T] {
+	state := f.get()
+	select {
+	case <-state.done:
+		return state.res
+	default:
+	}
+	select {
+	case <-state.done:
+		return state.res
+	case <-ctx.Done():
+		return NewFailure[T](ctx.Err())
+	}
+}
+
+// Done returns a channel closed once the Future is complete.
+func (f Future[T]) Done() <-chan struct{} {
+	return f.get().done
+}
+
+// Cancel cancels the context of the computation. It does nothing if the Future
+// is already complete.
+func (f Future[T]) Cancel() {
+	f.get().cancel()
+}
+
+// Then returns a Future applying fn to the success value of f with MapCtx
+// semantics. It runs under the context f was started with.
+func Then[T, U any](f Future[T], fn func(context.Context, T) U) Future[U] {
+	return Async(f.get().parent, func(ctx context.Context) Result[U] {
+		return MapCtx(ctx, f.Await(ctx), fn)
+	})
+}
+
+// ThenAsync returns a Future awaiting the Future returned by fn for the success
+// value of f, with FlatMapCtx semantics. It runs under the context f was
+// started with.
+func ThenAsync[T, U any](f Future[T], fn func(context.Context, T) Future[U]) Future[U] {
+	return Async(f.get().parent, func(ctx context.Context) Result[U] {
+		return FlatMapCtx(ctx, f.Await(ctx), func(ctx context.Context, value T) Result[U] {
+			return fn(ctx, value).Await(ctx)
+		})
+	})
+}
+
+type indexedResult[T any] struct {
+	index int
+	res   Result[T]
+}
+
+// awaitEach awaits every future in its own goroutine and sends their Results,
+// in completion order, on the returned channel.
+func awaitEach[T any](ctx context.Context, futures []Future[T]) <-chan indexedResult[T] {
+	results := make(chan indexedResult[T], len(futures))
+	for i, f := range futures {
+		go func(i int, f Future[T]) {
+			results <- indexedResult[T]{index: i, res: f.Await(ctx)}
+		}(i, f)
+	}
+	return results
+}
+
+// cancelEach cancels futures, those already complete are left as is.
+func cancelEach[T any](futures []Future[T]) {
+	for _, f := range futures {
+		f.Cancel()
+	}
+}
+
+// All returns a Future holding the success values of futures in their order. It
+// fails as soon as one of them fails, with its error, and cancels the others.
+func All[T any](ctx context.Context, futures ...Future[T]) Future[[]T] {
+	return Async(ctx, func(ctx context.Context) Result[[]T] {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		defer cancelEach(futures)
+		values := make([]T, len(futures))
+		results := awaitEach(ctx, futures)
+		for range futures {
+			r := <-results
+			if r.res.IsError() {
+				return NewFailure[[]T](r.res.AsError())
+			}
+			values[r.index] = r.res.Unwrap()
+		}
+		return NewSuccess(values)
+	})
+}
+
+// Any returns a Future holding the first success among futures and cancels the
+// others. If all of them fail, it fails with an fxerror.MultiError holding their
+// errors in order.
+func Any[T any](ctx context.Context, futures ...Future[T]) Future[T] {
+	return Async(ctx, func(ctx context.Context) Result[T] {
+		if len(futures) == 0 {
+			return NewFailure[T](fxerror.New(fxerror.ErrInvalidArgument, "Any requires at least one future"))
+		}
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		defer cancelEach(futures)
+		errs := make([]error, len(futures))
+		results := awaitEach(ctx, futures)
+		for range futures {
+			r := <-results
+			if r.res.IsSuccess() {
+				return r.res
+			}
+			errs[r.index] = r.res.AsError()
+		}
+		return NewFailure[T](fxerror.NewMultiError(errs...))
+	})
+}
+
+// Race returns a Future holding the Result of the first of futures to complete,
+// whether it succeeded or failed, and cancels the others.
+func Race[T any](ctx context.Context, futures ...Future[T]) Future[T] {
+	return Async(ctx, func(ctx context.Context) Result[T] {
+		if len(futures) == 0 {
+			return NewFailure[T](fxerror.New(fxerror.ErrInvalidArgument, "Race requires at least one future"))
+		}
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		defer cancelEach(futures)
+		return (<-awaitEach(ctx, futures)).res
+	})
+}
+
+// WithTimeout returns a Future holding the Result of f, or a failure holding
+// context.DeadlineExceeded if f does not complete within timeout, in which case
+// f is cancelled.
+func WithTimeout[T any](f Future[T], timeout time.Duration) Future[T] {
+	return Async(f.get().parent, func(ctx context.Context) Result[T] {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		res := f.Await(ctx)
+		if ctx.Err() != nil && res.IsError() {
+			f.Cancel()
+		}
+		return res
+	})
+}
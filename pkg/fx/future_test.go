@@ -0,0 +1,231 @@
+package fx
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	fxerror "github.com/fredsh/go-fxtend/pkg/fx-error"
+	"github.com/stretchr/testify/require"
+)
+
+// gated returns a Future completing with res once gate is closed, or with the
+// context error if it is cancelled first.
+func gated[T any](ctx context.Context, gate <-chan struct{}, res Result[T]) Future[T] {
+	return Async(ctx, func(ctx context.Context) Result[T] {
+		select {
+		case <-gate:
+			return res
+		case <-ctx.Done():
+			return NewFailure[T](ctx.Err())
+		}
+	})
+}
+
+func TestAsyncAwait(t *testing.T) {
+	ctx := context.Background()
+
+	f := Async(ctx, func(context.Context) Result[int] { return NewSuccess(1) })
+	require.Equal(t, 1, f.Await(ctx).Unwrap())
+	require.Equal(t, 1, f.Await(ctx).Unwrap(), "a Future can be awaited several times")
+	<-f.Done()
+
+	panicking := Async(ctx, func(context.Context) Result[int] { panic("boom") })
+	require.ErrorContains(t, panicking.Await(ctx).AsError(), "boom")
+
+	never := make(chan struct{})
+	pending := gated(ctx, never, NewSuccess(1))
+	short, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, pending.Await(short).AsError(), context.DeadlineExceeded)
+
+	pending.Cancel()
+	require.ErrorIs(t, pending.Await(ctx).AsError(), context.Canceled)
+
+	parent, cancelParent := context.WithCancel(ctx)
+	child := gated(parent, never, NewSuccess(1))
+	cancelParent()
+	require.ErrorIs(t, child.Await(ctx).AsError(), context.Canceled)
+
+	called := false
+	notStarted := Async(parent, func(context.Context) Result[int] {
+		called = true
+		return NewSuccess(1)
+	})
+	require.ErrorIs(t, notStarted.Await(ctx).AsError(), context.Canceled)
+	require.False(t, called)
+
+	var zero Future[int]
+	require.True(t, fxerror.IsInvalidArgument(zero.Await(ctx).AsError()), "a zero Future fails instead of panicking")
+	<-zero.Done()
+	zero.Cancel()
+}
+
+func TestThen(t *testing.T) {
+	ctx := context.Background()
+	errParse := errors.New("parse failed")
+
+	text := Async(ctx, func(context.Context) Result[string] { return NewSuccess("21") })
+	doubled := ThenAsync(text, func(ctx context.Context, s string) Future[int] {
+		return Async(ctx, func(context.Context) Result[int] {
+			return FlatMapErr(NewSuccess(s), strconv.Atoi)
+		})
+	})
+	answer := Then(doubled, func(_ context.Context, i int) int { return i * 2 })
+	require.Equal(t, 42, answer.Await(ctx).Unwrap())
+
+	failed := Then(Resolved(NewFailure[int](errParse)), func(_ context.Context, i int) int {
+		panic("not called on failure")
+	})
+	require.ErrorIs(t, failed.Await(ctx).AsError(), errParse)
+
+	chained := ThenAsync(Resolved(NewSuccess(1)), func(context.Context, int) Future[int] {
+		return Resolved(NewFailure[int](errParse))
+	})
+	require.ErrorIs(t, chained.Await(ctx).AsError(), errParse)
+}
+
+func TestCombinators(t *testing.T) {
+	ctx := context.Background()
+	errA := errors.New("a failed")
+	errB := errors.New("b failed")
+	never := make(chan struct{})
+
+	cases := []struct {
+		name    string
+		run     func() Result[any]
+		want    any
+		wantErr func(error) bool
+	}{
+		{
+			name: "All keeps the order of the futures",
+			run: func() Result[any] {
+				slow := make(chan struct{})
+				first := gated(ctx, slow, NewSuccess(1))
+				second := Resolved(NewSuccess(2))
+				all := All(ctx, first, second)
+				close(slow)
+				return Map(all.Await(ctx), func(v []int) any { return v })
+			},
+			want: []int{1, 2},
+		},
+		{
+			name: "All fails fast",
+			run: func() Result[any] {
+				all := All(ctx, gated(ctx, never, NewSuccess(1)), Resolved(NewFailure[int](errA)))
+				return Map(all.Await(ctx), func(v []int) any { return v })
+			},
+			wantErr: func(err error) bool { return errors.Is(err, errA) },
+		},
+		{
+			name: "All of nothing is empty",
+			run: func() Result[any] {
+				return Map(All[int](ctx).Await(ctx), func(v []int) any { return v })
+			},
+			want: []int{},
+		},
+		{
+			name: "Any returns the first success",
+			run: func() Result[any] {
+				res := Any(ctx, Resolved(NewFailure[int](errA)), gated(ctx, never, NewSuccess(1)), Resolved(NewSuccess(3)))
+				return Map(res.Await(ctx), func(v int) any { return v })
+			},
+			want: 3,
+		},
+		{
+			name: "Any aggregates every failure",
+			run: func() Result[any] {
+				res := Any(ctx, Resolved(NewFailure[int](errA)), Resolved(NewFailure[int](errB)))
+				return Map(res.Await(ctx), func(v int) any { return v })
+			},
+			wantErr: func(err error) bool {
+				var multi *fxerror.MultiError
+				return errors.As(err, &multi) && errors.Is(err, errA) && errors.Is(err, errB) && len(multi.Errors) == 2
+			},
+		},
+		{
+			name: "Any of nothing is invalid",
+			run: func() Result[any] {
+				return Map(Any[int](ctx).Await(ctx), func(v int) any { return v })
+			},
+			wantErr: fxerror.IsInvalidArgument,
+		},
+		{
+			name: "Race returns the first completion even if it failed",
+			run: func() Result[any] {
+				res := Race(ctx, gated(ctx, never, NewSuccess(1)), Resolved(NewFailure[int](errB)))
+				return Map(res.Await(ctx), func(v int) any { return v })
+			},
+			wantErr: func(err error) bool { return errors.Is(err, errB) },
+		},
+		{
+			name: "WithTimeout fails and cancels slow futures",
+			run: func() Result[any] {
+				slow := gated(ctx, never, NewSuccess(1))
+				res := WithTimeout(slow, 10*time.Millisecond).Await(ctx)
+				if !errors.Is(slow.Await(ctx).AsError(), context.Canceled) {
+					return NewFailure[any](errors.New("slow future was not cancelled"))
+				}
+				return Map(res, func(v int) any { return v })
+			},
+			wantErr: fxerror.IsTimeout,
+		},
+		{
+			name: "WithTimeout returns fast futures",
+			run: func() Result[any] {
+				res := WithTimeout(Resolved(NewSuccess(1)), time.Minute)
+				return Map(res.Await(ctx), func(v int) any { return v })
+			},
+			want: 1,
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			res := tt.run()
+			if tt.wantErr != nil {
+				require.True(t, tt.wantErr(res.AsError()), "unexpected error %v", res.AsError())
+				return
+			}
+			require.NoError(t, res.AsError())
+			require.Equal(t, tt.want, res.Unwrap())
+		})
+	}
+}
+
+func TestCombinatorsCancelLosers(t *testing.T) {
+	ctx := context.Background()
+	never := make(chan struct{})
+
+	cases := []struct {
+		name    string
+		combine func(loser Future[int]) Result[int]
+	}{
+		{
+			name: "All cancels the others on failure",
+			combine: func(loser Future[int]) Result[int] {
+				return Map(All(ctx, loser, Resolved(NewFailure[int](errors.New("failed")))).Await(ctx), func(v []int) int { return len(v) })
+			},
+		},
+		{
+			name: "Any cancels the others on success",
+			combine: func(loser Future[int]) Result[int] {
+				return Any(ctx, loser, Resolved(NewSuccess(1))).Await(ctx)
+			},
+		},
+		{
+			name: "Race cancels the others",
+			combine: func(loser Future[int]) Result[int] {
+				return Race(ctx, loser, Resolved(NewSuccess(1))).Await(ctx)
+			},
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			loser := gated(ctx, never, NewSuccess(2))
+			tt.combine(loser)
+			require.ErrorIs(t, loser.Await(ctx).AsError(), context.Canceled)
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package fx
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	fxerror "github.com/fredsh/go-fxtend/pkg/fx-error"
+)
+
+// GroupMode selects how a Group reacts to a failing task.
+type GroupMode int
+
+const (
+	// GroupFailFast cancels the context of the group on the first failure and
+	// reports that failure alone.
+	GroupFailFast GroupMode = iota
+	// GroupCollectAll lets every task run and reports all the failures.
+	GroupCollectAll
+)
+
+// GroupConfig configures a Group.
+type GroupConfig struct {
+	Limit int       // Maximum number of tasks running at once, 0 means unlimited.
+	Mode  GroupMode // Reaction to failing tasks.
+}
+
+// DefaultGroupConfig returns an unlimited fail fast configuration.
+func DefaultGroupConfig() GroupConfig {
+	return GroupConfig{
+		Mode: GroupFailFast,
+	}
+}
+
+// Group runs tasks concurrently and waits for all of them, like errgroup.Group.
+// Panicking tasks are recovered and reported as failures.
+type Group struct {
+	config GroupConfig
+	ctx    context.Context
+	cancel context.CancelFunc
+	sem    chan struct{}
+	wg     sync.WaitGroup
+
+	mu       sync.Mutex
+	errs     []error
+	firstErr error
+}
+
+// NewGroup creates a Group whose tasks run under a context derived from ctx,
+// configured from DefaultGroupConfig and the given enhancers.
+func NewGroup(ctx context.Context, opts ...OptionEnhancer[GroupConfig]) *Group {
+	config := OptionBuilder(DefaultGroupConfig, opts...)
+	ctx, cancel := context.WithCancel(ctx)
+	g := &Group{
+		config: config,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+	if config.Limit > 0 {
+		g.sem = make(chan struct{}, config.Limit)
+	}
+	return g
+}
+
+// Go runs task in a new goroutine, blocking while the concurrency limit is
+// reached. Like FlatMapCtx, task is not called once the context of the group is
+// done and ctx.Err() is recorded instead.
+func (g *Group) Go(task func(context.Context) error) {
+	g.mu.Lock()
+	index := len(g.errs)
+	g.errs = append(g.errs, nil)
+	g.mu.Unlock()
+
+	if g.sem != nil {
+		g.sem <- struct{}{}
+	}
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		if g.sem != nil {
+			defer func() { <-g.sem }()
+		}
+		g.record(index, g.run(task))
+	}()
+}
+
+func (g *Group) run(task func(context.Context) error) (err error) {
+	if g.ctx.Err() != nil {
+		return g.ctx.Err()
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fxerror.WithStack(fmt.Errorf("task panicked: %v", r))
+		}
+	}()
+	return task(g.ctx)
+}
+
+func (g *Group) record(index int, err error) {
+	if err == nil {
+		return
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.errs[index] = err
+	if g.firstErr == nil {
+		g.firstErr = err
+		if g.config.Mode == GroupFailFast {
+			g.cancel()
+		}
+	}
+}
+
+// Wait waits for every task and cancels the context of the group. In fail fast
+// mode it returns the first failure, in collect all mode an fxerror.MultiError
+// holding the failures in submission order. It returns nil if no task failed.
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	g.cancel()
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.config.Mode == GroupFailFast {
+		return g.firstErr
+	}
+	return fxerror.NewMultiError(g.errs...)
+}
+
+// ResultGroup is a Group whose tasks return Results, collected in submission
+// order.
+type ResultGroup[T any] struct {
+	group *Group
+
+	mu      sync.Mutex
+	results []Result[T]
+}
+
+// NewResultGroup creates a ResultGroup, see NewGroup.
+func NewResultGroup[T any](ctx context.Context, opts ...OptionEnhancer[GroupConfig]) *ResultGroup[T] {
+	return &ResultGroup[T]{group: NewGroup(ctx, opts...)}
+}
+
+// Go runs task in a new goroutine, see Group.Go.
+func (g *ResultGroup[T]) Go(task func(context.Context) Result[T]) {
+	g.mu.Lock()
+	index := len(g.results)
+	g.results = append(g.results, Result[T]{})
+	g.mu.Unlock()
+
+	g.group.Go(func(ctx context.Context) error {
+		res := task(ctx)
+		g.mu.Lock()
+		g.results[index] = res
+		g.mu.Unlock()
+		return res.AsError()
+	})
+}
+
+// Wait waits for every task and returns their success values in submission
+// order, or a failure holding the error Group.Wait would return.
+func (g *ResultGroup[T]) Wait() Result[[]T] {
+	if err := g.group.Wait(); err != nil {
+		return NewFailure[[]T](err)
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	values := make([]T, len(g.results))
+	for i, res := range g.results {
+		values[i] = res.Unwrap()
+	}
+	return NewSuccess(values)
+}
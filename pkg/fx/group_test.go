@@ -0,0 +1,140 @@
+package fx
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	fxerror "github.com/fredsh/go-fxtend/pkg/fx-error"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroup(t *testing.T) {
+	errA := errors.New("a failed")
+	errB := errors.New("b failed")
+
+	cases := []struct {
+		name    string
+		opts    []OptionEnhancer[GroupConfig]
+		tasks   []func(context.Context) error
+		wantErr func(error) bool
+	}{
+		{
+			name: "successful tasks produce no error",
+			tasks: []func(context.Context) error{
+				func(context.Context) error { return nil },
+				func(context.Context) error { return nil },
+			},
+			wantErr: func(err error) bool { return err == nil },
+		},
+		{
+			name: "fail fast returns the failure and cancels the others",
+			tasks: []func(context.Context) error{
+				func(context.Context) error { return errA },
+				func(ctx context.Context) error {
+					<-ctx.Done()
+					return ctx.Err()
+				},
+			},
+			wantErr: func(err error) bool { return errors.Is(err, errA) },
+		},
+		{
+			name: "collect all returns every failure in submission order",
+			opts: []OptionEnhancer[GroupConfig]{func(c GroupConfig) GroupConfig {
+				c.Mode = GroupCollectAll
+				return c
+			}},
+			tasks: []func(context.Context) error{
+				func(context.Context) error { return errA },
+				func(context.Context) error { return nil },
+				func(context.Context) error { return errB },
+			},
+			wantErr: func(err error) bool {
+				var multi *fxerror.MultiError
+				return errors.As(err, &multi) && len(multi.Errors) == 2 &&
+					multi.Errors[0] == errA && multi.Errors[1] == errB
+			},
+		},
+		{
+			name: "panics are recovered as failures",
+			tasks: []func(context.Context) error{
+				func(context.Context) error { panic("boom") },
+			},
+			wantErr: func(err error) bool { return err != nil && err.Error() == "task panicked: boom" },
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGroup(context.Background(), tt.opts...)
+			for _, task := range tt.tasks {
+				g.Go(task)
+			}
+			err := g.Wait()
+			require.True(t, tt.wantErr(err), "unexpected error %v", err)
+		})
+	}
+}
+
+func TestGroupLimit(t *testing.T) {
+	var running, peak int32
+	g := NewGroup(context.Background(), func(c GroupConfig) GroupConfig {
+		c.Limit = 2
+		return c
+	})
+	for i := 0; i < 10; i++ {
+		g.Go(func(context.Context) error {
+			n := atomic.AddInt32(&running, 1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+			atomic.AddInt32(&running, -1)
+			return nil
+		})
+	}
+	require.NoError(t, g.Wait())
+	require.LessOrEqual(t, atomic.LoadInt32(&peak), int32(2))
+
+	parent, cancel := context.WithCancel(context.Background())
+	cancel()
+	called := false
+	g = NewGroup(parent)
+	g.Go(func(context.Context) error {
+		called = true
+		return nil
+	})
+	require.ErrorIs(t, g.Wait(), context.Canceled)
+	require.False(t, called)
+}
+
+func TestResultGroup(t *testing.T) {
+	errA := errors.New("a failed")
+	ctx := context.Background()
+
+	g := NewResultGroup[int](ctx)
+	for i := 0; i < 5; i++ {
+		i := i
+		g.Go(func(context.Context) Result[int] { return NewSuccess(i * i) })
+	}
+	require.Equal(t, []int{0, 1, 4, 9, 16}, g.Wait().Unwrap())
+
+	g = NewResultGroup[int](ctx, func(c GroupConfig) GroupConfig {
+		c.Mode = GroupCollectAll
+		return c
+	})
+	g.Go(func(context.Context) Result[int] { return NewSuccess(1) })
+	g.Go(func(context.Context) Result[int] { return NewFailure[int](errA) })
+	g.Go(func(context.Context) Result[int] { panic("boom") })
+	res := g.Wait()
+	var multi *fxerror.MultiError
+	require.ErrorAs(t, res.AsError(), &multi)
+	require.Len(t, multi.Errors, 2)
+	require.ErrorIs(t, multi.Errors[0], errA)
+	require.ErrorContains(t, multi.Errors[1], "boom")
+
+	empty := NewResultGroup[string](ctx).Wait()
+	require.Equal(t, []string{}, empty.Unwrap())
+}
@@ -0,0 +1,161 @@
+package fx
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// lazyState holds an evaluated value.
+type lazyState[T any] struct {
+	value T
+}
+
+// lazyCell evaluates a value at most once at a time and caches it until reset.
+// The generation is bumped by every reset so that an evaluation started before a
+// reset does not publish its stale value.
+type lazyCell[T any] struct {
+	mu    sync.Mutex
+	state atomic.Pointer[lazyState[T]]
+	gen   atomic.Uint64
+}
+
+// get returns the cached value or evaluates fn, caching its value unless keep
+// rejects it. If fn panics, the panic propagates and nothing is cached.
+func (c *lazyCell[T]) get(fn func() T, keep func(T) bool) T {
+	if s := c.state.Load(); s != nil {
+		return s.value
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if s := c.state.Load(); s != nil {
+		return s.value
+	}
+	gen := c.gen.Load()
+	value := fn()
+	if keep == nil || keep(value) {
+		s := &lazyState[T]{value: value}
+		// a reset after the CAS clears the state itself, one before it bumped gen
+		if c.state.CompareAndSwap(nil, s) && c.gen.Load() != gen {
+			c.state.CompareAndSwap(s, nil)
+		}
+	}
+	return value
+}
+
+func (c *lazyCell[T]) evaluated() bool {
+	return c.state.Load() != nil
+}
+
+func (c *lazyCell[T]) reset() {
+	c.gen.Add(1)
+	c.state.Store(nil)
+}
+
+// Lazy is a value computed on first access and cached afterwards. Concurrent
+// accesses wait for a single evaluation, like sync.Once, but the value can be
+// reset to be evaluated again.
+type Lazy[T any] struct {
+	fn   func() T
+	cell lazyCell[T]
+}
+
+// NewLazy creates a Lazy evaluating fn on first access.
+func NewLazy[T any](fn func() T) *Lazy[T] {
+	return &Lazy[T]{fn: fn}
+}
+
+// Get returns the value, evaluating it if needed.
+func (l *Lazy[T]) Get() T {
+	return l.cell.get(l.fn, nil)
+}
+
+// IsEvaluated returns true if the value is cached.
+func (l *Lazy[T]) IsEvaluated() bool {
+	return l.cell.evaluated()
+}
+
+// Reset drops the cached value so the next access evaluates it again. An
+// evaluation in progress during Reset returns its value to its callers but does
+// not cache it.
+func (l *Lazy[T]) Reset() {
+	l.cell.reset()
+}
+
+// LazyConfig configures a LazyResult.
+type LazyConfig struct {
+	RetryOnError bool // Do not cache failures, the next access evaluates again.
+}
+
+// LazyResult is a Result computed on first access and cached afterwards, with
+// the same semantics as Lazy.
+type LazyResult[T any] struct {
+	fn     func() Result[T]
+	config LazyConfig
+	cell   lazyCell[Result[T]]
+}
+
+// NewLazyResult creates a LazyResult evaluating fn on first access.
+func NewLazyResult[T any](fn func() Result[T], opts ...OptionEnhancer[LazyConfig]) *LazyResult[T] {
+	return &LazyResult[T]{
+		fn:     fn,
+		config: OptionBuilder(func() LazyConfig { return LazyConfig{} }, opts...),
+	}
+}
+
+// Get returns the Result, evaluating it if needed.
+func (l *LazyResult[T]) Get() Result[T] {
+	if !l.config.RetryOnError {
+		return l.cell.get(l.fn, nil)
+	}
+	return l.cell.get(l.fn, Result[T].IsSuccess)
+}
+
+// IsEvaluated returns true if the Result is cached.
+func (l *LazyResult[T]) IsEvaluated() bool {
+	return l.cell.evaluated()
+}
+
+// Reset drops the cached Result so the next access evaluates it again.
+func (l *LazyResult[T]) Reset() {
+	l.cell.reset()
+}
+
+// LazyMap returns a Lazy applying fn to the value of l. Neither l nor fn are
+// evaluated until the returned Lazy is.
+func LazyMap[T, U any](l *Lazy[T], fn func(T) U) *Lazy[U] {
+	return NewLazy(func() U {
+		return fn(l.Get())
+	})
+}
+
+// LazyFlatMap returns a Lazy evaluating the Lazy returned by fn for the value of
+// l. Neither l nor fn are evaluated until the returned Lazy is.
+func LazyFlatMap[T, U any](l *Lazy[T], fn func(T) *Lazy[U]) *Lazy[U] {
+	return NewLazy(func() U {
+		return fn(l.Get()).Get()
+	})
+}
+
+// LazyResultMap returns a LazyResult applying fn to the success value of l with
+// Map semantics. It keeps the configuration of l and does not evaluate anything
+// until it is accessed.
+func LazyResultMap[T, U any](l *LazyResult[T], fn func(T) U) *LazyResult[U] {
+	return &LazyResult[U]{
+		fn: func() Result[U] {
+			return Map(l.Get(), fn)
+		},
+		config: l.config,
+	}
+}
+
+// LazyResultFlatMap returns a LazyResult applying fn to the success value of l
+// with FlatMap semantics. It keeps the configuration of l and does not evaluate
+// anything until it is accessed.
+func LazyResultFlatMap[T, U any](l *LazyResult[T], fn func(T) Result[U]) *LazyResult[U] {
+	return &LazyResult[U]{
+		fn: func() Result[U] {
+			return FlatMap(l.Get(), fn)
+		},
+		config: l.config,
+	}
+}
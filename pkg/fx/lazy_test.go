@@ -0,0 +1,163 @@
+package fx
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLazy(t *testing.T) {
+	var calls atomic.Int32
+	l := NewLazy(func() int {
+		calls.Add(1)
+		return 42
+	})
+	require.False(t, l.IsEvaluated())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			require.Equal(t, 42, l.Get())
+		}()
+	}
+	wg.Wait()
+	require.True(t, l.IsEvaluated())
+	require.Equal(t, int32(1), calls.Load())
+
+	l.Reset()
+	require.False(t, l.IsEvaluated())
+	require.Equal(t, 42, l.Get())
+	require.Equal(t, int32(2), calls.Load())
+}
+
+func TestLazyPanicIsNotCached(t *testing.T) {
+	shouldPanic := true
+	l := NewLazy(func() string {
+		if shouldPanic {
+			panic("boom")
+		}
+		return "ok"
+	})
+	require.Panics(t, func() { l.Get() })
+	require.False(t, l.IsEvaluated())
+
+	shouldPanic = false
+	require.Equal(t, "ok", l.Get())
+}
+
+func TestLazyResult(t *testing.T) {
+	errLoad := errors.New("load failed")
+
+	cases := []struct {
+		name      string
+		opts      []OptionEnhancer[LazyConfig]
+		wantCalls int
+		wantErr   bool
+	}{
+		{
+			name:      "failures are cached by default",
+			wantCalls: 1,
+			wantErr:   true,
+		},
+		{
+			name: "failures are evaluated again with retry on error",
+			opts: []OptionEnhancer[LazyConfig]{func(c LazyConfig) LazyConfig {
+				c.RetryOnError = true
+				return c
+			}},
+			wantCalls: 2,
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			l := NewLazyResult(func() Result[int] {
+				calls++
+				if calls == 1 {
+					return NewFailure[int](errLoad)
+				}
+				return NewSuccess(calls)
+			}, tt.opts...)
+
+			require.ErrorIs(t, l.Get().AsError(), errLoad)
+			res := l.Get()
+			l.Get()
+			require.Equal(t, tt.wantCalls, calls)
+			if tt.wantErr {
+				require.ErrorIs(t, res.AsError(), errLoad)
+			} else {
+				require.Equal(t, 2, res.Unwrap())
+			}
+		})
+	}
+}
+
+func TestLazyMapDoesNotForce(t *testing.T) {
+	evaluated := []string{}
+	base := NewLazy(func() int {
+		evaluated = append(evaluated, "base")
+		return 2
+	})
+	doubled := LazyMap(base, func(i int) int {
+		evaluated = append(evaluated, "map")
+		return i * 2
+	})
+	text := LazyFlatMap(doubled, func(i int) *Lazy[string] {
+		evaluated = append(evaluated, "flatMap")
+		return NewLazy(func() string { return strconv.Itoa(i) })
+	})
+	require.Empty(t, evaluated)
+	require.False(t, base.IsEvaluated())
+
+	require.Equal(t, "4", text.Get())
+	require.Equal(t, "4", text.Get())
+	require.Equal(t, []string{"base", "map", "flatMap"}, evaluated)
+
+	calls := 0
+	source := NewLazyResult(func() Result[string] {
+		calls++
+		return NewSuccess("12")
+	})
+	parsed := LazyResultFlatMap(source, func(s string) Result[int] {
+		return FlatMapErr(NewSuccess(s), strconv.Atoi)
+	})
+	incremented := LazyResultMap(parsed, func(i int) int { return i + 1 })
+	require.Equal(t, 0, calls)
+	require.Equal(t, 13, incremented.Get().Unwrap())
+	require.Equal(t, 12, parsed.Get().Unwrap())
+	require.Equal(t, 1, calls)
+
+	failing := LazyResultMap(NewLazyResult(func() Result[int] {
+		return NewFailure[int](errors.New("boom"))
+	}), func(i int) int { return i })
+	require.EqualError(t, failing.Get().AsError(), "boom")
+}
+
+func TestLazyResetDuringEvaluation(t *testing.T) {
+	var calls atomic.Int32
+	started, release := make(chan struct{}), make(chan struct{})
+	l := NewLazy(func() int {
+		if calls.Add(1) == 1 {
+			close(started)
+			<-release
+		}
+		return int(calls.Load())
+	})
+
+	done := make(chan int)
+	go func() { done <- l.Get() }()
+	<-started
+	l.Reset()
+	close(release)
+
+	require.Equal(t, 1, <-done)
+	require.False(t, l.IsEvaluated(), "a value evaluated across a Reset is not cached")
+	require.Equal(t, 2, l.Get())
+	require.True(t, l.IsEvaluated())
+}
@@ -0,0 +1,126 @@
+package fx
+
+import (
+	"reflect"
+
+	fxerror "github.com/fredsh/go-fxtend/pkg/fx-error"
+)
+
+// MapChange holds the old and new values of a key present in both maps.
+type MapChange[V any] struct {
+	Old V
+	New V
+}
+
+// MapDiffResult lists the differences between two maps.
+type MapDiffResult[K comparable, V any] struct {
+	Added   map[K]V            // Entries only present in the second map.
+	Removed map[K]V            // Entries only present in the first map.
+	Changed map[K]MapChange[V] // Entries whose value differs.
+}
+
+// IsEmpty returns true if both maps hold the same entries.
+func (d MapDiffResult[K, V]) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// MapDiff compares the map a with the map b, values being compared with eq or
+// with reflect.DeepEqual if eq is nil.
+func MapDiff[K comparable, V any](a, b map[K]V, eq func(V, V) bool) MapDiffResult[K, V] {
+	eq = mapValueEq(eq)
+	res := MapDiffResult[K, V]{
+		Added:   map[K]V{},
+		Removed: map[K]V{},
+		Changed: map[K]MapChange[V]{},
+	}
+	for k, old := range a {
+		v, ok := b[k]
+		if !ok {
+			res.Removed[k] = old
+		} else if !eq(old, v) {
+			res.Changed[k] = MapChange[V]{Old: old, New: v}
+		}
+	}
+	for k, v := range b {
+		if _, ok := a[k]; !ok {
+			res.Added[k] = v
+		}
+	}
+	return res
+}
+
+// MapMerge3 merges the changes made to base by ours and theirs, see
+// MapMerge3Func.
+func MapMerge3[K comparable, V comparable](base, ours, theirs map[K]V) (map[K]V, error) {
+	return MapMerge3Func(base, ours, theirs, func(a, b V) bool { return a == b })
+}
+
+// MapMerge3Func merges the changes made to base by ours and theirs, values being
+// compared with eq or with reflect.DeepEqual if eq is nil. A key added, changed
+// or removed by only one side takes that side's value, a key changed the same
+// way by both sides takes it too.
+//
+// A key changed differently by both sides is a conflict: it keeps its base
+// value, or stays absent, and is reported as an fxerror.ConflictError whose
+// values are Maybe[V], None standing for an absent key. The conflicts are
+// returned, in no particular order, as an fxerror.MultiError along with the
+// merged map.
+func MapMerge3Func[K comparable, V any](base, ours, theirs map[K]V, eq func(V, V) bool) (map[K]V, error) {
+	eq = mapValueEq(eq)
+	same := func(a, b Maybe[V]) bool {
+		if a.IsNone() || b.IsNone() {
+			return a.IsNone() == b.IsNone()
+		}
+		return eq(a.Unwrap(), b.Unwrap())
+	}
+
+	res := make(map[K]V, len(ours))
+	var conflicts []error
+	merge := func(k K) {
+		b, o, t := mapLookup(base, k), mapLookup(ours, k), mapLookup(theirs, k)
+		var merged Maybe[V]
+		switch {
+		case same(o, t), same(b, t):
+			merged = o
+		case same(b, o):
+			merged = t
+		default:
+			conflicts = append(conflicts, fxerror.NewConflictError(k, b, o, t))
+			merged = b
+		}
+		if merged.IsSome() {
+			res[k] = merged.Unwrap()
+		}
+	}
+
+	for k := range base {
+		merge(k)
+	}
+	for k := range ours {
+		if _, ok := base[k]; !ok {
+			merge(k)
+		}
+	}
+	for k := range theirs {
+		_, inBase := base[k]
+		_, inOurs := ours[k]
+		if !inBase && !inOurs {
+			merge(k)
+		}
+	}
+	return res, fxerror.NewMultiError(conflicts...)
+}
+
+func mapLookup[K comparable, V any](m map[K]V, k K) Maybe[V] {
+	if v, ok := m[k]; ok {
+		return NewSome(v)
+	}
+	return NewNone[V]()
+}
+
+func mapValueEq[V any](eq func(V, V) bool) func(V, V) bool {
+	if eq != nil {
+		return eq
+	}
+	return func(a, b V) bool { return reflect.DeepEqual(a, b) }
+}
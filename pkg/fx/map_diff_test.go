@@ -0,0 +1,123 @@
+package fx
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	fxerror "github.com/fredsh/go-fxtend/pkg/fx-error"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapDiff(t *testing.T) {
+	cases := []struct {
+		name string
+		a    map[string]int
+		b    map[string]int
+		eq   func(int, int) bool
+		want MapDiffResult[string, int]
+	}{
+		{
+			name: "empty maps produce an empty diff",
+			want: MapDiffResult[string, int]{Added: map[string]int{}, Removed: map[string]int{}, Changed: map[string]MapChange[int]{}},
+		},
+		{
+			name: "added, removed and changed entries are reported",
+			a:    map[string]int{"kept": 1, "removed": 2, "changed": 3},
+			b:    map[string]int{"kept": 1, "added": 4, "changed": 5},
+			want: MapDiffResult[string, int]{
+				Added:   map[string]int{"added": 4},
+				Removed: map[string]int{"removed": 2},
+				Changed: map[string]MapChange[int]{"changed": {Old: 3, New: 5}},
+			},
+		},
+		{
+			name: "custom equality is used",
+			a:    map[string]int{"a": 10},
+			b:    map[string]int{"a": 14},
+			eq:   func(x, y int) bool { return x/10 == y/10 },
+			want: MapDiffResult[string, int]{Added: map[string]int{}, Removed: map[string]int{}, Changed: map[string]MapChange[int]{}},
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MapDiff(tt.a, tt.b, tt.eq)
+			require.Equal(t, tt.want, got)
+			require.Equal(t, tt.want.IsEmpty(), got.IsEmpty())
+		})
+	}
+
+	slices := MapDiff(map[int][]int{1: {1}}, map[int][]int{1: {1}}, nil)
+	require.True(t, slices.IsEmpty(), "nil equality falls back to reflect.DeepEqual")
+}
+
+func TestMapMerge3(t *testing.T) {
+	cases := []struct {
+		name          string
+		base          map[string]int
+		ours          map[string]int
+		theirs        map[string]int
+		want          map[string]int
+		wantConflicts []string
+	}{
+		{
+			name:   "one sided changes are applied",
+			base:   map[string]int{"a": 1, "b": 2, "c": 3},
+			ours:   map[string]int{"a": 10, "b": 2, "c": 3, "d": 4},
+			theirs: map[string]int{"a": 1, "c": 3, "e": 5},
+			want:   map[string]int{"a": 10, "c": 3, "d": 4, "e": 5},
+		},
+		{
+			name:   "identical changes on both sides are applied",
+			base:   map[string]int{"a": 1, "b": 2},
+			ours:   map[string]int{"a": 7, "c": 3},
+			theirs: map[string]int{"a": 7, "c": 3},
+			want:   map[string]int{"a": 7, "c": 3},
+		},
+		{
+			name:          "diverging changes are conflicts",
+			base:          map[string]int{"changed": 1, "deleted": 2, "kept": 3},
+			ours:          map[string]int{"changed": 10, "kept": 3, "added": 1},
+			theirs:        map[string]int{"changed": 20, "deleted": 5, "kept": 3, "added": 2},
+			want:          map[string]int{"changed": 1, "deleted": 2, "kept": 3},
+			wantConflicts: []string{"added", "changed", "deleted"},
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MapMerge3(tt.base, tt.ours, tt.theirs)
+			require.Equal(t, tt.want, got)
+			if tt.wantConflicts == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.True(t, fxerror.IsConflict(err))
+			var multi *fxerror.MultiError
+			require.ErrorAs(t, err, &multi)
+			keys := []string{}
+			for _, e := range multi.Errors {
+				var conflict *fxerror.ConflictError
+				require.True(t, errors.As(e, &conflict))
+				keys = append(keys, conflict.Key.(string))
+			}
+			require.ElementsMatch(t, tt.wantConflicts, keys)
+		})
+	}
+
+	_, err := MapMerge3(map[string]int{"k": 1}, map[string]int{}, map[string]int{"k": 2})
+	var conflict *fxerror.ConflictError
+	require.ErrorAs(t, err, &conflict)
+	require.Equal(t, NewSome(1), conflict.Base)
+	require.Equal(t, NewNone[int](), conflict.Ours)
+	require.Equal(t, NewSome(2), conflict.Theirs)
+	require.Equal(t, "conflict: [k] base [Some(1)] ours [None] theirs [Some(2)]", conflict.Error())
+
+	got, err := MapMerge3Func(
+		map[string]string{"k": "a"},
+		map[string]string{"k": "A"},
+		map[string]string{"k": "b"},
+		strings.EqualFold,
+	)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"k": "b"}, got)
+}
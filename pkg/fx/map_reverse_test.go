@@ -67,7 +67,11 @@ func TestReverseMap(t *testing.T) {
 			resX := MapReverseX(tt.input)
 			if tt.wantErr {
 				require.Error(t, err)
-				require.ErrorAs(t, resX.AsError(), &fxerror.ErrDuplicateValue)
+				var valueErr *fxerror.ValueError
+				require.ErrorAs(t, resX.AsError(), &valueErr)
+				require.True(t, fxerror.IsDuplicateValue(resX.AsError()))
+				require.True(t, fxerror.IsDuplicateValue(err))
+				require.Equal(t, fxerror.CodeDuplicateValue, fxerror.CodeOf(err))
 				for k := range tt.want {
 					require.Contains(t, resWithOverride, k)
 				}
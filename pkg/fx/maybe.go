@@ -3,7 +3,8 @@ package fx
 import (
 	"bytes"
 	"encoding/json"
-	"errors"
+
+	fxerror "github.com/fredsh/go-fxtend/pkg/fx-error"
 )
 
 type Maybe[T any] struct {
@@ -38,7 +39,7 @@ func (m Maybe[T]) Unwrap() T {
 func (o Maybe[T]) Get() (T, error) {
 	if !o.isSet {
 		var defaultValue T
-		return defaultValue, errors.New("no value set in Maybe")
+		return defaultValue, fxerror.New(fxerror.ErrNotFound, "no value set in Maybe")
 	}
 	return o.value, nil
 }
@@ -0,0 +1,37 @@
+package fx
+
+import (
+	"fmt"
+	"io"
+
+	fxconv "github.com/fredsh/go-fxtend/pkg/internal/fx-conv"
+)
+
+// String implements fmt.Stringer, it prints Some(value) or None.
+func (m Maybe[T]) String() string {
+	return fmt.Sprint(m)
+}
+
+// Format implements fmt.Formatter. %v prints Some(value) or None, %+v adds the
+// type parameter and %#v prints the Go syntax building the Maybe. Other verbs
+// are applied to the inner value.
+func (m Maybe[T]) Format(f fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && f.Flag('#'):
+		if !m.isSet {
+			fmt.Fprintf(f, "fx.NewNone[%s]()", fxconv.TypeName[T]())
+			return
+		}
+		fmt.Fprintf(f, "fx.NewSome[%s](%#v)", fxconv.TypeName[T](), m.value)
+	case verb == 'v' && f.Flag('+'):
+		if !m.isSet {
+			fmt.Fprintf(f, "None[%s]", fxconv.TypeName[T]())
+			return
+		}
+		fmt.Fprintf(f, "Some[%s](%+v)", fxconv.TypeName[T](), m.value)
+	case !m.isSet:
+		_, _ = io.WriteString(f, "None")
+	default:
+		fmt.Fprintf(f, "Some("+fxconv.Directive(f, verb)+")", m.value)
+	}
+}
@@ -0,0 +1,83 @@
+package fx
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	fxerror "github.com/fredsh/go-fxtend/pkg/fx-error"
+	fxtypes "github.com/fredsh/go-fxtend/pkg/fx-types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormat(t *testing.T) {
+	wrapped := fmt.Errorf("loading: %w", fxerror.NewDuplicateValueError(1))
+
+	cases := []struct {
+		name   string
+		format string
+		value  any
+		want   string
+	}{
+		{name: "Some with %v", format: "%v", value: NewSome(3), want: "Some(3)"},
+		{name: "None with %v", format: "%v", value: NewNone[int](), want: "None"},
+		{name: "Some with %s", format: "%s", value: NewSome("x"), want: "Some(x)"},
+		{name: "Some with %q", format: "%q", value: NewSome("x"), want: `Some("x")`},
+		{name: "Some with width and precision", format: "%6.2f", value: NewSome(3.14159), want: "Some(  3.14)"},
+		{name: "None with %d", format: "%d", value: NewNone[int](), want: "None"},
+		{name: "Some with %+v", format: "%+v", value: NewSome(3), want: "Some[int](3)"},
+		{name: "None with %+v", format: "%+v", value: NewNone[string](), want: "None[string]"},
+		{name: "Some with %#v", format: "%#v", value: NewSome("x"), want: `fx.NewSome[string]("x")`},
+		{name: "None with %#v", format: "%#v", value: NewNone[error](), want: "fx.NewNone[error]()"},
+		{name: "nested Some with %v", format: "%v", value: NewSome(NewSome(NewNone[int]())), want: "Some(Some(None))"},
+		{name: "nested Some with %+v", format: "%+v", value: NewSome(NewSome(1)), want: "Some[fx.Maybe[int]](Some[int](1))"},
+		{name: "nested Some with %#v", format: "%#v", value: NewSome(NewSome(1)), want: "fx.NewSome[fx.Maybe[int]](fx.NewSome[int](1))"},
+		{name: "slice of Some with %v", format: "%v", value: []Maybe[int]{NewSome(1), NewNone[int]()}, want: "[Some(1) None]"},
+		{name: "Ok with %v", format: "%v", value: NewSuccess(3), want: "Ok(3)"},
+		{name: "Ok with %x", format: "%x", value: NewSuccess(255), want: "Ok(ff)"},
+		{name: "Err with %v", format: "%v", value: NewFailure[int](errors.New("boom")), want: "Err(boom)"},
+		{name: "Ok with %+v", format: "%+v", value: NewSuccess(NewSome(2)), want: "Ok[fx.Maybe[int]](Some[int](2))"},
+		{
+			name:   "Err with %+v prints the error chain",
+			format: "%+v",
+			value:  NewFailure[int](wrapped),
+			want: "Err[int](*fmt.wrapError: loading: duplicate value encountered: [1]; " +
+				"*fxerror.ValueError: duplicate value encountered: [1]; " +
+				"*errors.errorString: duplicate value encountered)",
+		},
+		{
+			name:   "Err with %+v walks multiple errors",
+			format: "%+v",
+			value:  NewFailure[int](fxerror.NewMultiError(wrapped, errors.New("boom"))),
+			want: "Err[int](*fxerror.MultiError: 2 errors occurred: loading: duplicate value encountered: [1]; boom; " +
+				"*fmt.wrapError: loading: duplicate value encountered: [1]; " +
+				"*fxerror.ValueError: duplicate value encountered: [1]; " +
+				"*errors.errorString: duplicate value encountered; " +
+				"*errors.errorString: boom)",
+		},
+		{name: "Ok with %#v", format: "%#v", value: NewSuccess(Maybe[int]{}), want: "fx.NewSuccess[fx.Maybe[int]](fx.NewNone[int]())"},
+		{name: "Err with %#v", format: "%#v", value: NewFailure[int](errors.New("boom")), want: `fx.NewFailure[int](&errors.errorString{s:"boom"})`},
+		{name: "Ok of Maybe with %v", format: "%v", value: NewSuccess(NewNone[int]()), want: "Ok(None)"},
+		{name: "Option with %v", format: "%v", value: fxtypes.Some(3), want: "Some(3)"},
+		{name: "empty Option with %#v", format: "%#v", value: fxtypes.NewNoneNone[int](), want: "fxtypes.NewNoneNone[int]()"},
+		{name: "legacy Result with %v", format: "%v", value: fxtypes.NewSuccessResult(fxtypes.Some(1)), want: "Ok(Some(1))"},
+		{name: "legacy Result with %#v", format: "%#v", value: fxtypes.NewSuccessResult(1), want: "fxtypes.NewSuccessResult[int](1)"},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, fmt.Sprintf(tt.format, tt.value))
+		})
+	}
+}
+
+func TestStringer(t *testing.T) {
+	var _ fmt.Stringer = NewSome(1)
+	var _ fmt.Stringer = NewSuccess(1)
+	var _ fmt.Stringer = fxtypes.Some(1)
+	var _ fmt.Stringer = fxtypes.NewSuccessResult(1)
+
+	require.Equal(t, "Some(1)", NewSome(1).String())
+	require.Equal(t, "None", NewNone[int]().String())
+	require.Equal(t, "Err(boom)", NewFailure[int](errors.New("boom")).String())
+	require.Equal(t, "Some(Ok(x))", NewSome(NewSuccess("x")).String())
+}
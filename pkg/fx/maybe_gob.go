@@ -0,0 +1,52 @@
+package fx
+
+import (
+	"bytes"
+	"encoding/gob"
+)
+
+// GobEncode implements gob.GobEncoder. Maybe fields are unexported so gob would
+// otherwise encode it as an empty struct.
+func (m Maybe[T]) GobEncode() ([]byte, error) {
+	var buf bytes.Buffer
+	enc := gob.NewEncoder(&buf)
+	if err := enc.Encode(m.isSet); err != nil {
+		return nil, err
+	}
+	if m.isSet {
+		if err := enc.Encode(&m.value); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode implements gob.GobDecoder.
+func (m *Maybe[T]) GobDecode(data []byte) error {
+	dec := gob.NewDecoder(bytes.NewReader(data))
+	var isSet bool
+	if err := dec.Decode(&isSet); err != nil {
+		return err
+	}
+	if !isSet {
+		*m = NewNone[T]()
+		return nil
+	}
+
+	var value T
+	if err := dec.Decode(&value); err != nil {
+		return err
+	}
+	*m = NewSome(value)
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler using the gob encoding.
+func (m Maybe[T]) MarshalBinary() ([]byte, error) {
+	return m.GobEncode()
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler using the gob encoding.
+func (m *Maybe[T]) UnmarshalBinary(data []byte) error {
+	return m.GobDecode(data)
+}
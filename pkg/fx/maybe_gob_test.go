@@ -0,0 +1,74 @@
+package fx
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"testing"
+
+	fxtypes "github.com/fredsh/go-fxtend/pkg/fx-types"
+	"github.com/stretchr/testify/require"
+)
+
+func gobRoundTrip[T any](t *testing.T, in T) T {
+	var buf bytes.Buffer
+	require.NoError(t, gob.NewEncoder(&buf).Encode(in))
+	var out T
+	require.NoError(t, gob.NewDecoder(&buf).Decode(&out))
+	return out
+}
+
+func TestMaybeGob(t *testing.T) {
+	type cached struct {
+		ID    int
+		Score Maybe[float64]
+		Tags  Maybe[[]Maybe[string]]
+	}
+
+	require.Equal(t, NewSome(0), gobRoundTrip(t, NewSome(0)))
+	require.Equal(t, NewNone[int](), gobRoundTrip(t, NewNone[int]()))
+	require.Equal(t, NewSome("x"), gobRoundTrip(t, NewSome("x")))
+
+	nested := NewSome([]Maybe[int]{NewSome(1), NewNone[int](), NewSome(3)})
+	require.Equal(t, nested, gobRoundTrip(t, nested))
+
+	deep := NewSome(NewSome(NewNone[string]()))
+	require.Equal(t, deep, gobRoundTrip(t, deep))
+
+	byKey := NewSome(map[string]Maybe[int]{"a": NewSome(1), "b": NewNone[int]()})
+	require.Equal(t, byKey, gobRoundTrip(t, byKey))
+
+	value := cached{
+		ID:    7,
+		Score: NewSome(0.5),
+		Tags:  NewSome([]Maybe[string]{NewSome("a"), NewNone[string]()}),
+	}
+	require.Equal(t, value, gobRoundTrip(t, value))
+	require.Equal(t, cached{ID: 1}, gobRoundTrip(t, cached{ID: 1}))
+
+	option := fxtypes.Some([]fxtypes.Option[int]{fxtypes.Some(1), fxtypes.NewNoneNone[int]()})
+	require.Equal(t, option, gobRoundTrip(t, option))
+}
+
+func TestResultGob(t *testing.T) {
+	success := NewSuccess([]Maybe[int]{NewSome(1), NewNone[int]()})
+	require.Equal(t, success, gobRoundTrip(t, success))
+
+	failure := gobRoundTrip(t, NewFailure[int](errors.New("boom")))
+	require.True(t, failure.IsError())
+	require.EqualError(t, failure.AsError(), "boom")
+
+	legacy := gobRoundTrip(t, fxtypes.NewSuccessResult(NewSome(2)))
+	require.Equal(t, fxtypes.NewSuccessResult(NewSome(2)), legacy)
+}
+
+func TestMaybeBinary(t *testing.T) {
+	data, err := NewSome(NewSome(5)).MarshalBinary()
+	require.NoError(t, err)
+
+	var got Maybe[Maybe[int]]
+	require.NoError(t, got.UnmarshalBinary(data))
+	require.Equal(t, NewSome(NewSome(5)), got)
+
+	require.Error(t, got.UnmarshalBinary([]byte("garbage")))
+}
@@ -0,0 +1,29 @@
+//go:build go1.21
+
+package fx
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+// LogValue implements slog.LogValuer. None resolves to an empty group, which
+// handlers leave out of the record, Some resolves to the inner value.
+func (m Maybe[T]) LogValue() slog.Value {
+	if !m.isSet {
+		return slog.GroupValue()
+	}
+	return slog.AnyValue(m.value)
+}
+
+// LogValue implements slog.LogValuer. A success resolves to its value, a failure
+// to a group holding the error message and the error type.
+func (r Result[T]) LogValue() slog.Value {
+	if r.err != nil {
+		return slog.GroupValue(
+			slog.String("error", r.err.Error()),
+			slog.String("type", fmt.Sprintf("%T", r.err)),
+		)
+	}
+	return slog.AnyValue(r.value)
+}
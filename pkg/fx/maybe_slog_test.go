@@ -0,0 +1,94 @@
+//go:build go1.21
+
+package fx
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	fxerror "github.com/fredsh/go-fxtend/pkg/fx-error"
+	fxtypes "github.com/fredsh/go-fxtend/pkg/fx-types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogValue(t *testing.T) {
+	cases := []struct {
+		name string
+		attr slog.Attr
+		want string
+	}{
+		{
+			name: "None is left out",
+			attr: slog.Any("v", NewNone[int]()),
+			want: `{"msg":"m"}`,
+		},
+		{
+			name: "Some logs the inner value",
+			attr: slog.Any("v", NewSome(3)),
+			want: `{"msg":"m","v":3}`,
+		},
+		{
+			name: "nested Some logs the innermost value",
+			attr: slog.Any("v", NewSome(NewSome("x"))),
+			want: `{"msg":"m","v":"x"}`,
+		},
+		{
+			name: "empty Option is left out",
+			attr: slog.Any("v", fxtypes.NewNoneNone[int]()),
+			want: `{"msg":"m"}`,
+		},
+		{
+			name: "success logs the value",
+			attr: slog.Any("v", NewSuccess([]int{1, 2})),
+			want: `{"msg":"m","v":[1,2]}`,
+		},
+		{
+			name: "failure logs the error and its type",
+			attr: slog.Any("v", NewFailure[int](errors.New("boom"))),
+			want: `{"msg":"m","v":{"error":"boom","type":"*errors.errorString"}}`,
+		},
+		{
+			name: "legacy failure logs the error and its type",
+			attr: slog.Any("v", fxtypes.NewErrorResult[int](fxerror.NewDuplicateValueError(1))),
+			want: `{"msg":"m","v":{"error":"duplicate value encountered: [1]","type":"*fxerror.ValueError"}}`,
+		},
+		{
+			name: "value error exposes value and category",
+			attr: slog.Any("err", fxerror.NewDuplicateValueError("a")),
+			want: `{"msg":"m","err":{"msg":"duplicate value encountered: [a]","value":"a","category":"duplicate_value"}}`,
+		},
+		{
+			name: "error attribute unwraps value errors",
+			attr: fxerror.Attr(fmt.Errorf("loading: %w", fxerror.NewDuplicateValueError(2))),
+			want: `{"msg":"m","error":{"msg":"loading: duplicate value encountered: [2]","value":2,"category":"duplicate_value"}}`,
+		},
+		{
+			name: "error attribute exposes the category",
+			attr: fxerror.Attr(fmt.Errorf("db: %w", fxerror.ErrUnavailable)),
+			want: `{"msg":"m","error":{"msg":"db: unavailable","category":"unavailable"}}`,
+		},
+		{
+			name: "error attribute falls back to the message",
+			attr: fxerror.Attr(errors.New("boom")),
+			want: `{"msg":"m","error":"boom"}`,
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+				ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+					if len(groups) == 0 && (a.Key == slog.TimeKey || a.Key == slog.LevelKey) {
+						return slog.Attr{}
+					}
+					return a
+				},
+			}))
+			logger.Info("m", tt.attr)
+			require.JSONEq(t, tt.want, buf.String())
+		})
+	}
+}
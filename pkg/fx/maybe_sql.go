@@ -0,0 +1,34 @@
+package fx
+
+import (
+	"database/sql/driver"
+
+	fxconv "github.com/fredsh/go-fxtend/pkg/internal/fx-conv"
+)
+
+// Scan implements sql.Scanner. A NULL column produces None, any other value is
+// converted into T and stored as Some. If *T implements sql.Scanner itself, the
+// conversion is delegated to it.
+func (m *Maybe[T]) Scan(src any) error {
+	if src == nil {
+		*m = NewNone[T]()
+		return nil
+	}
+
+	var value T
+	if err := fxconv.Scan(&value, src); err != nil {
+		return err
+	}
+	*m = NewSome(value)
+	return nil
+}
+
+// Value implements driver.Valuer. None is stored as NULL, Some is converted
+// with the driver's default parameter converter, which honours a driver.Valuer
+// implemented by T.
+func (m Maybe[T]) Value() (driver.Value, error) {
+	if !m.isSet {
+		return nil, nil
+	}
+	return driver.DefaultParameterConverter.ConvertValue(m.value)
+}
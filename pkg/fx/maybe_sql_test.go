@@ -0,0 +1,167 @@
+package fx
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	fxtypes "github.com/fredsh/go-fxtend/pkg/fx-types"
+	"github.com/stretchr/testify/require"
+)
+
+// echoDriver is a fake database driver whose queries return a single row made
+// of the arguments they were given, so values go through both driver.Valuer and
+// sql.Scanner.
+type echoDriver struct{}
+
+type echoConn struct{}
+
+type echoStmt struct{}
+
+type echoRows struct {
+	values []driver.Value
+	done   bool
+}
+
+func (echoDriver) Open(string) (driver.Conn, error) { return echoConn{}, nil }
+
+func (echoConn) Prepare(string) (driver.Stmt, error) { return echoStmt{}, nil }
+func (echoConn) Close() error                        { return nil }
+func (echoConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+func (echoStmt) Close() error  { return nil }
+func (echoStmt) NumInput() int { return -1 }
+func (echoStmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, errors.New("not supported")
+}
+func (echoStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return &echoRows{values: args}, nil
+}
+
+func (r *echoRows) Columns() []string {
+	cols := make([]string, len(r.values))
+	for i := range cols {
+		cols[i] = "c" + strconv.Itoa(i)
+	}
+	return cols
+}
+func (r *echoRows) Close() error { return nil }
+func (r *echoRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	copy(dest, r.values)
+	return nil
+}
+
+func init() {
+	sql.Register("fx-echo", echoDriver{})
+}
+
+// shouting scans any string upper-cased and stores it lower-cased.
+type shouting string
+
+func (s *shouting) Scan(src any) error {
+	str, ok := src.(string)
+	if !ok {
+		return errors.New("shouting expects a string")
+	}
+	*s = shouting(strings.ToUpper(str))
+	return nil
+}
+
+func (s shouting) Value() (driver.Value, error) {
+	return strings.ToLower(string(s)), nil
+}
+
+type status string
+
+func openEchoDB(t *testing.T) *sql.DB {
+	db, err := sql.Open("fx-echo", "")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+func TestMaybeScanValue(t *testing.T) {
+	db := openEchoDB(t)
+	now := time.Date(2023, 5, 17, 10, 30, 0, 0, time.UTC)
+
+	cases := []struct {
+		name    string
+		arg     any
+		dest    any
+		want    any
+		wantErr bool
+	}{
+		{name: "NULL into string produce None", arg: nil, dest: &Maybe[string]{}, want: NewNone[string]()},
+		{name: "string into string produce Some", arg: "hello", dest: &Maybe[string]{}, want: NewSome("hello")},
+		{name: "bytes into string produce Some", arg: []byte("hello"), dest: &Maybe[string]{}, want: NewSome("hello")},
+		{name: "int64 into int produce Some", arg: int64(42), dest: &Maybe[int]{}, want: NewSome(42)},
+		{name: "int64 into int8 overflow produce an error", arg: int64(300), dest: &Maybe[int8]{}, wantErr: true},
+		{name: "bytes into int64 produce Some", arg: []byte("-7"), dest: &Maybe[int64]{}, want: NewSome[int64](-7)},
+		{name: "int64 into uint32 produce Some", arg: int64(7), dest: &Maybe[uint32]{}, want: NewSome[uint32](7)},
+		{name: "float64 into float32 produce Some", arg: 1.5, dest: &Maybe[float32]{}, want: NewSome[float32](1.5)},
+		{name: "int64 into bool produce Some", arg: int64(1), dest: &Maybe[bool]{}, want: NewSome(true)},
+		{name: "bool into bool produce Some", arg: false, dest: &Maybe[bool]{}, want: NewSome(false)},
+		{name: "time into time produce Some", arg: now, dest: &Maybe[time.Time]{}, want: NewSome(now)},
+		{name: "string into time produce an error", arg: "now", dest: &Maybe[time.Time]{}, wantErr: true},
+		{name: "RFC3339 string into time produce Some", arg: "2023-05-17T10:30:00Z", dest: &Maybe[time.Time]{}, want: NewSome(now)},
+		{name: "SQL datetime bytes into time produce Some", arg: []byte("2023-05-17 10:30:00"), dest: &Maybe[time.Time]{}, want: NewSome(now)},
+		{name: "bytes into bytes produce Some", arg: []byte{1, 2}, dest: &Maybe[[]byte]{}, want: NewSome([]byte{1, 2})},
+		{name: "string into named string produce Some", arg: "active", dest: &Maybe[status]{}, want: NewSome(status("active"))},
+		{name: "string into Scanner is delegated", arg: "quiet", dest: &Maybe[shouting]{}, want: NewSome(shouting("QUIET"))},
+		{name: "int64 into Scanner error is reported", arg: int64(1), dest: &Maybe[shouting]{}, wantErr: true},
+		{name: "NULL into Option produce None", arg: nil, dest: &fxtypes.Option[int]{}, want: fxtypes.NewNoneNone[int]()},
+		{name: "int64 into Option produce Some", arg: int64(3), dest: &fxtypes.Option[int]{}, want: fxtypes.Some(3)},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := db.QueryRow("echo", tt.arg).Scan(tt.dest)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, deref(tt.dest))
+		})
+	}
+}
+
+func TestMaybeValueRoundTrip(t *testing.T) {
+	db := openEchoDB(t)
+
+	var gotNone Maybe[int]
+	require.NoError(t, db.QueryRow("echo", NewNone[int]()).Scan(&gotNone))
+	require.True(t, gotNone.IsNone())
+
+	var gotInt Maybe[int]
+	require.NoError(t, db.QueryRow("echo", NewSome(12)).Scan(&gotInt))
+	require.Equal(t, NewSome(12), gotInt)
+
+	var gotStatus Maybe[status]
+	require.NoError(t, db.QueryRow("echo", NewSome(status("done"))).Scan(&gotStatus))
+	require.Equal(t, NewSome(status("done")), gotStatus)
+
+	var gotValuer Maybe[shouting]
+	require.NoError(t, db.QueryRow("echo", NewSome(shouting("LoUd"))).Scan(&gotValuer))
+	require.Equal(t, NewSome(shouting("LOUD")), gotValuer)
+
+	var gotOption fxtypes.Option[string]
+	require.NoError(t, db.QueryRow("echo", fxtypes.Some("x")).Scan(&gotOption))
+	require.Equal(t, fxtypes.Some("x"), gotOption)
+
+	_, err := NewSome(struct{}{}).Value()
+	require.Error(t, err)
+}
+
+func deref(v any) any {
+	return reflect.ValueOf(v).Elem().Interface()
+}
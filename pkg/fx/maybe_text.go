@@ -0,0 +1,59 @@
+package fx
+
+import (
+	"errors"
+
+	fxconv "github.com/fredsh/go-fxtend/pkg/internal/fx-conv"
+	"gopkg.in/yaml.v3"
+)
+
+// MarshalYAML implements yaml.Marshaler. None is encoded as null.
+func (m Maybe[T]) MarshalYAML() (interface{}, error) {
+	if !m.isSet {
+		return nil, nil
+	}
+	return m.value, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler. An explicit null produces None, an
+// absent key leaves the Maybe untouched.
+func (m *Maybe[T]) UnmarshalYAML(node *yaml.Node) error {
+	var value *T
+	if err := node.Decode(&value); err != nil {
+		return err
+	}
+	if value == nil {
+		*m = NewNone[T]()
+		return nil
+	}
+	*m = NewSome(*value)
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler. None is encoded as an empty text,
+// so a Some value whose text is empty is rejected rather than read back as None.
+func (m Maybe[T]) MarshalText() ([]byte, error) {
+	if !m.isSet {
+		return []byte{}, nil
+	}
+	text, err := fxconv.FormatText(m.value)
+	if err == nil && len(text) == 0 {
+		return nil, errors.New("cannot marshal Some of an empty text, it would read back as None")
+	}
+	return text, err
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. An empty text produces None.
+func (m *Maybe[T]) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*m = NewNone[T]()
+		return nil
+	}
+
+	var value T
+	if err := fxconv.ParseText(&value, text); err != nil {
+		return err
+	}
+	*m = NewSome(value)
+	return nil
+}
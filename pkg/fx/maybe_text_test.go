@@ -0,0 +1,127 @@
+package fx
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	fxtypes "github.com/fredsh/go-fxtend/pkg/fx-types"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestMaybeYAML(t *testing.T) {
+	type config struct {
+		Timeout Maybe[time.Duration]   `yaml:"timeout"`
+		Name    Maybe[string]          `yaml:"name"`
+		Ports   Maybe[[]int]           `yaml:"ports"`
+		Retries fxtypes.Option[int]    `yaml:"retries"`
+		Nested  Maybe[Maybe[string]]   `yaml:"nested"`
+		Legacy  fxtypes.Option[string] `yaml:"legacy"`
+	}
+
+	cases := []struct {
+		name    string
+		input   string
+		want    config
+		wantErr bool
+	}{
+		{
+			name:  "empty document produce None everywhere",
+			input: "",
+			want:  config{},
+		},
+		{
+			name:  "explicit null produce None",
+			input: "timeout: null\nname: ~\nretries:\n",
+			want:  config{},
+		},
+		{
+			name:  "values produce Some",
+			input: "timeout: 5s\nname: svc\nports: [80, 443]\nretries: 3\nnested: inner\nlegacy: old\n",
+			want: config{
+				Timeout: NewSome(5 * time.Second),
+				Name:    NewSome("svc"),
+				Ports:   NewSome([]int{80, 443}),
+				Retries: fxtypes.Some(3),
+				Nested:  NewSome(NewSome("inner")),
+				Legacy:  fxtypes.Some("old"),
+			},
+		},
+		{
+			name:    "invalid value produce an error",
+			input:   "timeout: soon\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var got config
+			err := yaml.Unmarshal([]byte(tt.input), &got)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+
+			out, err := yaml.Marshal(got)
+			require.NoError(t, err)
+			var roundTrip config
+			require.NoError(t, yaml.Unmarshal(out, &roundTrip))
+			require.Equal(t, tt.want, roundTrip)
+		})
+	}
+}
+
+func TestMaybeText(t *testing.T) {
+	var duration Maybe[time.Duration]
+	require.NoError(t, duration.UnmarshalText([]byte("1m30s")))
+	require.Equal(t, NewSome(90*time.Second), duration)
+	text, err := duration.MarshalText()
+	require.NoError(t, err)
+	require.Equal(t, "1m30s", string(text))
+
+	var instant Maybe[time.Time]
+	require.NoError(t, instant.UnmarshalText([]byte("2023-05-17T10:30:00Z")))
+	require.Equal(t, time.Date(2023, 5, 17, 10, 30, 0, 0, time.UTC), instant.Unwrap())
+
+	var count fxtypes.Option[uint8]
+	require.NoError(t, count.UnmarshalText([]byte("7")))
+	require.Equal(t, fxtypes.Some[uint8](7), count)
+	require.Error(t, count.UnmarshalText([]byte("700")))
+
+	var empty Maybe[int]
+	require.NoError(t, empty.UnmarshalText(nil))
+	require.True(t, empty.IsNone())
+	text, err = empty.MarshalText()
+	require.NoError(t, err)
+	require.Empty(t, text)
+
+	_, err = NewSome(struct{}{}).MarshalText()
+	require.Error(t, err)
+
+	for _, word := range []string{"word", ""} {
+		var roundTrip Maybe[string]
+		text, err := NewSome(word).MarshalText()
+		if word == "" {
+			require.Error(t, err, "Some of an empty text cannot round trip")
+			_, err = fxtypes.Some("").MarshalText()
+			require.Error(t, err)
+			continue
+		}
+		require.NoError(t, err)
+		require.NoError(t, roundTrip.UnmarshalText(text))
+		require.Equal(t, NewSome(word), roundTrip)
+	}
+}
+
+func TestResultYAML(t *testing.T) {
+	errBoom := errors.New("boom")
+	out, err := yaml.Marshal(map[string]Result[int]{"a": NewSuccess(1)})
+	require.NoError(t, err)
+	require.Equal(t, "a: 1\n", string(out))
+
+	_, err = yaml.Marshal(map[string]Result[int]{"a": NewFailure[int](errBoom)})
+	require.ErrorIs(t, err, errBoom)
+}
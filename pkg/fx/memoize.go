@@ -0,0 +1,224 @@
+package fx
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// MemoizeConfig configures Memoize and MemoizeCtx.
+type MemoizeConfig struct {
+	TTL           time.Duration // Lifetime of a cached value, 0 keeps it forever.
+	MaxSize       int           // Maximum number of cached values, least recently used are evicted first. 0 means unbounded.
+	SingleFlight  bool          // Concurrent calls for the same key wait for a single computation.
+	CacheFailures bool          // Cache failed Results too, context errors are never cached.
+	Clock         Clock         // Defaults to SystemClock.
+}
+
+// DefaultMemoizeConfig returns an unbounded configuration without expiry which
+// de-duplicates concurrent calls and does not cache failures.
+func DefaultMemoizeConfig() MemoizeConfig {
+	return MemoizeConfig{
+		SingleFlight: true,
+	}
+}
+
+// Memoize returns a function caching the values returned by fn per key.
+// The returned function is safe for concurrent use.
+func Memoize[K comparable, V any](fn func(K) V, opts ...OptionEnhancer[MemoizeConfig]) func(K) V {
+	m := newMemo[K, V](opts)
+	return func(key K) V {
+		return m.get(context.Background(), key, func(context.Context) Result[V] {
+			return NewSuccess(fn(key))
+		}).Unwrap()
+	}
+}
+
+// MemoizeCtx returns a function caching the Results returned by fn per key. Like
+// FlatMapCtx, a cancelled context produces a failure holding ctx.Err(), a caller
+// waiting for an in-flight computation stops waiting when its context is done.
+// The returned function is safe for concurrent use.
+func MemoizeCtx[K comparable, V any](fn func(context.Context, K) Result[V], opts ...OptionEnhancer[MemoizeConfig]) func(context.Context, K) Result[V] {
+	m := newMemo[K, V](opts)
+	return func(ctx context.Context, key K) Result[V] {
+		if ctx.Err() != nil {
+			return NewFailure[V](ctx.Err())
+		}
+		return m.get(ctx, key, func(ctx context.Context) Result[V] {
+			return fn(ctx, key)
+		})
+	}
+}
+
+type memoEntry[K comparable, V any] struct {
+	key       K
+	res       Result[V]
+	expiresAt time.Time
+	expiry    *list.Element // Position in memo.expiry, nil without TTL.
+}
+
+type memoCall[V any] struct {
+	done    chan struct{}
+	res     Result[V]
+	waiters int // Callers waiting for res, guarded by memo.mu.
+}
+
+type memo[K comparable, V any] struct {
+	config   MemoizeConfig
+	mu       sync.Mutex
+	entries  map[K]*list.Element
+	lru      *list.List
+	expiry   *list.List // Entries ordered by expiry, the TTL being the same for all.
+	inflight map[K]*memoCall[V]
+}
+
+func newMemo[K comparable, V any](opts []OptionEnhancer[MemoizeConfig]) *memo[K, V] {
+	config := OptionBuilder(DefaultMemoizeConfig, opts...)
+	if config.Clock == nil {
+		config.Clock = SystemClock()
+	}
+	return &memo[K, V]{
+		config:   config,
+		entries:  map[K]*list.Element{},
+		lru:      list.New(),
+		expiry:   list.New(),
+		inflight: map[K]*memoCall[V]{},
+	}
+}
+
+// get returns the cached Result for key or computes it. A caller waiting for the
+// computation of another one retries when that computation failed with a context
+// error while its own context is still alive.
+func (m *memo[K, V]) get(ctx context.Context, key K, compute func(context.Context) Result[V]) Result[V] {
+	for {
+		m.mu.Lock()
+		if res, ok := m.lookup(key); ok {
+			m.mu.Unlock()
+			return res
+		}
+		if !m.config.SingleFlight {
+			m.mu.Unlock()
+			return m.compute(ctx, key, nil, compute)
+		}
+		pending, ok := m.inflight[key]
+		if !ok {
+			call := &memoCall[V]{done: make(chan struct{})}
+			m.inflight[key] = call
+			m.mu.Unlock()
+			return m.compute(ctx, key, call, compute)
+		}
+		pending.waiters++
+		m.mu.Unlock()
+
+		select {
+		case <-pending.done:
+		case <-ctx.Done():
+			return NewFailure[V](ctx.Err())
+		}
+		if !isContextErr(pending.res.AsError()) || ctx.Err() != nil {
+			return pending.res
+		}
+	}
+}
+
+// compute runs compute for key, publishing its Result to the callers waiting on
+// call if it is not nil.
+func (m *memo[K, V]) compute(ctx context.Context, key K, call *memoCall[V], compute func(context.Context) Result[V]) Result[V] {
+	completed := false
+	defer func() {
+		if call == nil {
+			return
+		}
+		if !completed {
+			call.res = NewFailure[V](fmt.Errorf("memoized computation panicked for key %v", key))
+		}
+		m.mu.Lock()
+		delete(m.inflight, key)
+		m.mu.Unlock()
+		close(call.done)
+	}()
+
+	res := compute(ctx)
+	completed = true
+	if call != nil {
+		call.res = res
+	}
+	if res.IsSuccess() || (m.config.CacheFailures && !isContextErr(res.AsError())) {
+		m.mu.Lock()
+		m.store(key, res)
+		m.mu.Unlock()
+	}
+	return res
+}
+
+// lookup must be called with mu held.
+func (m *memo[K, V]) lookup(key K) (Result[V], bool) {
+	elem, ok := m.entries[key]
+	if !ok {
+		return Result[V]{}, false
+	}
+	entry := elem.Value.(*memoEntry[K, V])
+	if !entry.expiresAt.IsZero() && !m.config.Clock.Now().Before(entry.expiresAt) {
+		m.remove(elem)
+		return Result[V]{}, false
+	}
+	m.lru.MoveToFront(elem)
+	return entry.res, true
+}
+
+// store must be called with mu held. It drops the expired entries first so that
+// they do not accumulate when MaxSize is 0.
+func (m *memo[K, V]) store(key K, res Result[V]) {
+	var expiresAt time.Time
+	if m.config.TTL > 0 {
+		now := m.config.Clock.Now()
+		m.sweep(now)
+		expiresAt = now.Add(m.config.TTL)
+	}
+	if elem, ok := m.entries[key]; ok {
+		entry := elem.Value.(*memoEntry[K, V])
+		entry.res, entry.expiresAt = res, expiresAt
+		m.lru.MoveToFront(elem)
+		if entry.expiry != nil {
+			m.expiry.MoveToBack(entry.expiry)
+		}
+		return
+	}
+	entry := &memoEntry[K, V]{key: key, res: res, expiresAt: expiresAt}
+	elem := m.lru.PushFront(entry)
+	m.entries[key] = elem
+	if m.config.TTL > 0 {
+		entry.expiry = m.expiry.PushBack(elem)
+	}
+	if m.config.MaxSize > 0 && m.lru.Len() > m.config.MaxSize {
+		m.remove(m.lru.Back())
+	}
+}
+
+// sweep must be called with mu held, it drops the entries expired at now.
+func (m *memo[K, V]) sweep(now time.Time) {
+	for front := m.expiry.Front(); front != nil; front = m.expiry.Front() {
+		elem := front.Value.(*list.Element)
+		if now.Before(elem.Value.(*memoEntry[K, V]).expiresAt) {
+			return
+		}
+		m.remove(elem)
+	}
+}
+
+// remove must be called with mu held.
+func (m *memo[K, V]) remove(elem *list.Element) {
+	entry := elem.Value.(*memoEntry[K, V])
+	m.lru.Remove(elem)
+	if entry.expiry != nil {
+		m.expiry.Remove(entry.expiry)
+	}
+	delete(m.entries, entry.key)
+}
+
+func isContextErr(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,210 @@
+package fx
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoize(t *testing.T) {
+	calls := map[int]int{}
+	square := Memoize(func(i int) int {
+		calls[i]++
+		return i * i
+	})
+
+	require.Equal(t, 4, square(2))
+	require.Equal(t, 4, square(2))
+	require.Equal(t, 9, square(3))
+	require.Equal(t, map[int]int{2: 1, 3: 1}, calls)
+}
+
+func TestMemoizeOptions(t *testing.T) {
+	cases := []struct {
+		name      string
+		opts      []OptionEnhancer[MemoizeConfig]
+		scenario  func(clock *fakeClock, call func(int) int)
+		wantCalls int
+	}{
+		{
+			name: "values expire after the TTL",
+			opts: []OptionEnhancer[MemoizeConfig]{func(c MemoizeConfig) MemoizeConfig {
+				c.TTL = time.Minute
+				return c
+			}},
+			scenario: func(clock *fakeClock, call func(int) int) {
+				call(1)
+				clock.Advance(59 * time.Second)
+				call(1)
+				clock.Advance(time.Second)
+				call(1)
+			},
+			wantCalls: 2,
+		},
+		{
+			name: "least recently used values are evicted",
+			opts: []OptionEnhancer[MemoizeConfig]{func(c MemoizeConfig) MemoizeConfig {
+				c.MaxSize = 2
+				return c
+			}},
+			scenario: func(clock *fakeClock, call func(int) int) {
+				call(1)
+				call(2)
+				call(1)
+				call(3) // evicts 2
+				call(1)
+				call(2)
+			},
+			wantCalls: 4,
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := newFakeClock()
+			calls := 0
+			opts := append([]OptionEnhancer[MemoizeConfig]{func(c MemoizeConfig) MemoizeConfig {
+				c.Clock = clock
+				return c
+			}}, tt.opts...)
+			fn := Memoize(func(i int) int {
+				calls++
+				return i
+			}, opts...)
+			tt.scenario(clock, fn)
+			require.Equal(t, tt.wantCalls, calls)
+		})
+	}
+}
+
+func TestMemoizeCtxFailures(t *testing.T) {
+	errLookup := errors.New("lookup failed")
+	calls := 0
+	lookup := func(ctx context.Context, key string) Result[int] {
+		calls++
+		if key == "bad" {
+			return NewFailure[int](errLookup)
+		}
+		return FlatMapErr(NewSuccess(key), strconv.Atoi)
+	}
+	ctx := context.Background()
+
+	memoized := MemoizeCtx(lookup)
+	require.Equal(t, 1, memoized(ctx, "1").Unwrap())
+	require.Equal(t, 1, memoized(ctx, "1").Unwrap())
+	require.ErrorIs(t, memoized(ctx, "bad").AsError(), errLookup)
+	require.ErrorIs(t, memoized(ctx, "bad").AsError(), errLookup)
+	require.Equal(t, 3, calls, "failures are not cached by default")
+
+	calls = 0
+	cachingFailures := MemoizeCtx(lookup, func(c MemoizeConfig) MemoizeConfig {
+		c.CacheFailures = true
+		return c
+	})
+	require.ErrorIs(t, cachingFailures(ctx, "bad").AsError(), errLookup)
+	require.ErrorIs(t, cachingFailures(ctx, "bad").AsError(), errLookup)
+	require.Equal(t, 1, calls)
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	require.ErrorIs(t, cachingFailures(cancelled, "2").AsError(), context.Canceled)
+	require.Equal(t, 1, calls, "a cancelled context does not call the function")
+}
+
+func TestMemoizeCtxSingleFlight(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	memoized := MemoizeCtx(func(ctx context.Context, key int) Result[int] {
+		calls.Add(1)
+		<-release
+		return NewSuccess(key * 10)
+	})
+
+	const callers = 10
+	var wg sync.WaitGroup
+	results := make([]Result[int], callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = memoized(context.Background(), 7)
+		}(i)
+	}
+	require.Eventually(t, func() bool { return calls.Load() == 1 }, time.Second, time.Millisecond)
+
+	waiting, cancel := context.WithCancel(context.Background())
+	waited := make(chan Result[int])
+	go func() { waited <- memoized(waiting, 7) }()
+	cancel()
+	require.ErrorIs(t, (<-waited).AsError(), context.Canceled)
+
+	close(release)
+	wg.Wait()
+	require.Equal(t, int32(1), calls.Load())
+	for _, res := range results {
+		require.Equal(t, 70, res.Unwrap())
+	}
+}
+
+func TestMemoizeCtxFollowerRetriesCancelledLeader(t *testing.T) {
+	m := newMemo[int, int](nil)
+	var calls atomic.Int32
+	leaderStarted := make(chan struct{})
+	compute := func(ctx context.Context) Result[int] {
+		if calls.Add(1) == 1 {
+			close(leaderStarted)
+			<-ctx.Done()
+			return NewFailure[int](ctx.Err())
+		}
+		return NewSuccess(70)
+	}
+
+	leaderCtx, cancelLeader := context.WithCancel(context.Background())
+	leader := make(chan Result[int])
+	go func() { leader <- m.get(leaderCtx, 7, compute) }()
+	<-leaderStarted
+
+	follower := make(chan Result[int])
+	go func() { follower <- m.get(context.Background(), 7, compute) }()
+	require.Eventually(t, func() bool {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		call, ok := m.inflight[7]
+		return ok && call.waiters == 1
+	}, time.Second, time.Millisecond, "the follower waits on the leader")
+	cancelLeader()
+
+	require.ErrorIs(t, (<-leader).AsError(), context.Canceled)
+	require.Equal(t, 70, (<-follower).Unwrap(), "a live follower computes again instead of inheriting the cancellation")
+	require.Equal(t, int32(2), calls.Load())
+}
+
+func TestMemoizeSweepsExpiredEntries(t *testing.T) {
+	clock := newFakeClock()
+	m := newMemo[int, int]([]OptionEnhancer[MemoizeConfig]{func(c MemoizeConfig) MemoizeConfig {
+		c.TTL = time.Minute
+		c.Clock = clock
+		return c
+	}})
+	compute := func(v int) func(context.Context) Result[int] {
+		return func(context.Context) Result[int] { return NewSuccess(v) }
+	}
+
+	for key := 0; key < 10; key++ {
+		m.get(context.Background(), key, compute(key))
+	}
+	clock.Advance(30 * time.Second)
+	m.get(context.Background(), 0, compute(0))
+	m.get(context.Background(), 10, compute(10))
+	require.Len(t, m.entries, 11)
+
+	clock.Advance(45 * time.Second)
+	m.get(context.Background(), 11, compute(11))
+	require.Len(t, m.entries, 2, "keys expired without being read are dropped on insert")
+	require.Equal(t, m.lru.Len(), m.expiry.Len())
+}
@@ -0,0 +1,121 @@
+package fx
+
+import "reflect"
+
+// SlicePolicy tells Merge how to combine a slice of the base with the slice of
+// the override.
+type SlicePolicy int
+
+const (
+	// SliceReplace uses the override slice.
+	SliceReplace SlicePolicy = iota
+	// SliceAppend appends the override slice to the base slice.
+	SliceAppend
+	// SliceUnion appends the elements of the override slice missing from the
+	// base slice, compared with reflect.DeepEqual.
+	SliceUnion
+)
+
+// Merge returns base with the fields set in override merged over it. Zero
+// values in override, which include None Maybe fields, nil maps and nil slices,
+// leave the base value untouched, so a field cannot be reset to its zero value
+// by a merge: use Some(zero) for Maybe fields or an option applied afterwards.
+// Maps are merged key by key, recursively, and a key present in the override
+// is always set, even to a zero value. Slices are combined according to policy
+// and structs made only of exported fields, as well as the top-level struct, are
+// merged field by field. Other values, such as Maybe or time.Time, are replaced
+// by the override.
+//
+// Unexported fields of the top-level struct are kept from base. Neither base
+// nor override are modified and the result does not share maps or slices with
+// them, pointers are shared though.
+func Merge[T any](base, override T, policy SlicePolicy) T {
+	base, override = deepCopy(base), deepCopy(override)
+	var merged T
+	reflect.ValueOf(&merged).Elem().Set(mergeValue(reflect.ValueOf(&base).Elem(), reflect.ValueOf(&override).Elem(), policy, true))
+	return merged
+}
+
+// MergeOption returns an OptionEnhancer merging override over the options, see
+// Merge.
+func MergeOption[T any](override T, policy SlicePolicy) OptionEnhancer[T] {
+	return func(options T) T {
+		return Merge(options, override, policy)
+	}
+}
+
+func mergeValue(base, override reflect.Value, policy SlicePolicy, top bool) reflect.Value {
+	if override.IsZero() {
+		return base
+	}
+	if base.IsZero() && base.Kind() != reflect.Struct {
+		return override
+	}
+
+	switch base.Kind() {
+	case reflect.Struct:
+		if !top && !allExported(base.Type()) {
+			return override
+		}
+		merged := reflect.New(base.Type()).Elem()
+		merged.Set(base)
+		for i := 0; i < base.NumField(); i++ {
+			if !base.Type().Field(i).IsExported() {
+				continue
+			}
+			merged.Field(i).Set(mergeValue(base.Field(i), override.Field(i), policy, false))
+		}
+		return merged
+	case reflect.Pointer:
+		if base.Elem().Kind() != reflect.Struct {
+			return override
+		}
+		merged := reflect.New(base.Type().Elem())
+		merged.Elem().Set(mergeValue(base.Elem(), override.Elem(), policy, false))
+		return merged
+	case reflect.Map:
+		merged := reflect.MakeMapWithSize(base.Type(), base.Len()+override.Len())
+		iter := base.MapRange()
+		for iter.Next() {
+			merged.SetMapIndex(iter.Key(), iter.Value())
+		}
+		iter = override.MapRange()
+		for iter.Next() {
+			value := iter.Value()
+			if existing := base.MapIndex(iter.Key()); existing.IsValid() && !value.IsZero() {
+				value = mergeValue(existing, value, policy, false)
+			}
+			merged.SetMapIndex(iter.Key(), value)
+		}
+		return merged
+	case reflect.Slice:
+		return mergeSlice(base, override, policy)
+	}
+	return override
+}
+
+func mergeSlice(base, override reflect.Value, policy SlicePolicy) reflect.Value {
+	switch policy {
+	case SliceAppend:
+		merged := reflect.MakeSlice(base.Type(), 0, base.Len()+override.Len())
+		return reflect.AppendSlice(reflect.AppendSlice(merged, base), override)
+	case SliceUnion:
+		merged := reflect.AppendSlice(reflect.MakeSlice(base.Type(), 0, base.Len()+override.Len()), base)
+		for i := 0; i < override.Len(); i++ {
+			if !sliceContains(merged, override.Index(i)) {
+				merged = reflect.Append(merged, override.Index(i))
+			}
+		}
+		return merged
+	}
+	return override
+}
+
+func sliceContains(s reflect.Value, v reflect.Value) bool {
+	for i := 0; i < s.Len(); i++ {
+		if reflect.DeepEqual(s.Index(i).Interface(), v.Interface()) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+package fx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type mergeTLS struct {
+	Cert string
+	Key  string
+}
+
+type mergeConfig struct {
+	Name    string
+	Port    int
+	Timeout Maybe[time.Duration]
+	Tags    []string
+	Limits  map[string]map[string]int
+	TLS     *mergeTLS
+	Started time.Time
+	secret  string
+}
+
+func TestMerge(t *testing.T) {
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	base := mergeConfig{
+		Name:    "base",
+		Port:    80,
+		Timeout: NewSome(time.Second),
+		Tags:    []string{"a", "b"},
+		Limits:  map[string]map[string]int{"api": {"rps": 10, "burst": 20}},
+		TLS:     &mergeTLS{Cert: "base.crt", Key: "base.key"},
+		Started: start,
+		secret:  "kept",
+	}
+	override := mergeConfig{
+		Port:   8080,
+		Tags:   []string{"b", "c"},
+		Limits: map[string]map[string]int{"api": {"rps": 50}, "admin": {"rps": 1}},
+		TLS:    &mergeTLS{Cert: "override.crt"},
+		secret: "ignored",
+	}
+	merged := func(tags []string) mergeConfig {
+		return mergeConfig{
+			Name:    "base",
+			Port:    8080,
+			Timeout: NewSome(time.Second),
+			Tags:    tags,
+			Limits:  map[string]map[string]int{"api": {"rps": 50, "burst": 20}, "admin": {"rps": 1}},
+			TLS:     &mergeTLS{Cert: "override.crt", Key: "base.key"},
+			Started: start,
+			secret:  "kept",
+		}
+	}
+
+	cases := []struct {
+		name     string
+		base     mergeConfig
+		override mergeConfig
+		policy   SlicePolicy
+		want     mergeConfig
+	}{
+		{name: "replace policy uses the override slice", base: base, override: override, policy: SliceReplace, want: merged([]string{"b", "c"})},
+		{name: "append policy concatenates slices", base: base, override: override, policy: SliceAppend, want: merged([]string{"a", "b", "b", "c"})},
+		{name: "union policy adds missing elements", base: base, override: override, policy: SliceUnion, want: merged([]string{"a", "b", "c"})},
+		{name: "zero override keeps base", base: base, override: mergeConfig{}, want: base},
+		{
+			name:     "zero map values are set explicitly",
+			base:     base,
+			override: mergeConfig{Limits: map[string]map[string]int{"api": {"rps": 0}}},
+			want: func() mergeConfig {
+				c := base
+				c.Limits = map[string]map[string]int{"api": {"rps": 0, "burst": 20}}
+				return c
+			}(),
+		},
+		{
+			name:     "set Maybe and opaque structs replace base",
+			base:     base,
+			override: mergeConfig{Timeout: NewSome(time.Duration(0)), Started: start.Add(time.Hour)},
+			want: func() mergeConfig {
+				c := base
+				c.Timeout = NewSome(time.Duration(0))
+				c.Started = start.Add(time.Hour)
+				return c
+			}(),
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, Merge(tt.base, tt.override, tt.policy))
+		})
+	}
+
+	require.Equal(t, map[string]int{"rps": 10, "burst": 20}, base.Limits["api"], "base is not modified")
+	require.Equal(t, []string{"a", "b"}, base.Tags)
+	require.Equal(t, "base.key", base.TLS.Key)
+	require.Empty(t, override.TLS.Key)
+
+	result := Merge(base, mergeConfig{Port: 1}, SliceReplace)
+	result.Limits["api"]["rps"] = 99
+	result.Tags[0] = "z"
+	require.Equal(t, 10, base.Limits["api"]["rps"], "nested maps of the result are not shared with base")
+	require.Equal(t, "a", base.Tags[0])
+
+	require.Equal(t, 5, Merge(0, 5, SliceReplace))
+	require.Equal(t, 3, Merge[any](nil, 3, SliceReplace))
+	require.Equal(t, 3, Merge[any](3, nil, SliceReplace))
+	require.Nil(t, Merge[any](nil, nil, SliceReplace))
+	require.Equal(t, 3, Merge(3, 0, SliceReplace))
+	require.Equal(t, map[string]int{"a": 1, "b": 2}, Merge(map[string]int{"a": 1}, map[string]int{"b": 2}, SliceReplace))
+}
+
+func TestMergeOption(t *testing.T) {
+	config := OptionBuilder(
+		func() mergeConfig { return mergeConfig{Name: "default", Port: 80, Tags: []string{"a"}} },
+		MergeOption(mergeConfig{Port: 443, Tags: []string{"b"}}, SliceAppend),
+	)
+	require.Equal(t, mergeConfig{Name: "default", Port: 443, Tags: []string{"a", "b"}}, config)
+}
@@ -0,0 +1,145 @@
+package fx
+
+import (
+	"bytes"
+	"encoding/json"
+
+	fxerror "github.com/fredsh/go-fxtend/pkg/fx-error"
+)
+
+// Nullable is a tri-state optional value which tells apart a value that was never
+// provided (Undefined), a value explicitly set to null (Null) and an actual value.
+//
+// Its zero value is Undefined. Being a struct, encoding/json does not leave it
+// out for the `omitempty` tag option: encode PATCH bodies with MarshalPatch,
+// which honours `omitempty` for Undefined fields, or with json.Marshal and the
+// `omitzero` tag option from Go 1.24.
+type Nullable[T any] struct {
+	value T
+	set   bool
+	null  bool
+}
+
+// NewUndefined creates a Nullable that was never provided.
+func NewUndefined[T any]() Nullable[T] {
+	return Nullable[T]{}
+}
+
+// NewNull creates a Nullable explicitly set to null.
+func NewNull[T any]() Nullable[T] {
+	return Nullable[T]{set: true, null: true}
+}
+
+// NewValue creates a Nullable holding value.
+func NewValue[T any](value T) Nullable[T] {
+	return Nullable[T]{value: value, set: true}
+}
+
+// IsUndefined returns true if the Nullable was never provided.
+func (n Nullable[T]) IsUndefined() bool {
+	return !n.set
+}
+
+// IsZero returns true if the Nullable is Undefined, so that the `omitzero` JSON
+// tag option leaves it out.
+func (n Nullable[T]) IsZero() bool {
+	return !n.set
+}
+
+// IsDefined returns true if the Nullable was provided, either as null or as a value.
+func (n Nullable[T]) IsDefined() bool {
+	return n.set
+}
+
+// IsNull returns true if the Nullable was explicitly set to null.
+func (n Nullable[T]) IsNull() bool {
+	return n.set && n.null
+}
+
+// IsValue returns true if the Nullable holds a value.
+func (n Nullable[T]) IsValue() bool {
+	return n.set && !n.null
+}
+
+// Get returns the value or an error if the Nullable is Undefined or Null.
+func (n Nullable[T]) Get() (T, error) {
+	if !n.IsValue() {
+		var defaultValue T
+		return defaultValue, fxerror.New(fxerror.ErrNotFound, "no value set in Nullable")
+	}
+	return n.value, nil
+}
+
+// OrElse returns the value or def if the Nullable is Undefined or Null.
+func (n Nullable[T]) OrElse(def T) T {
+	if n.IsValue() {
+		return n.value
+	}
+	return def
+}
+
+// ToMaybe turns the Nullable into a Maybe, both Undefined and Null produce None.
+func (n Nullable[T]) ToMaybe() Maybe[T] {
+	if n.IsValue() {
+		return NewSome(n.value)
+	}
+	return NewNone[T]()
+}
+
+// Set makes the Nullable hold value.
+func (n *Nullable[T]) Set(value T) {
+	*n = NewValue(value)
+}
+
+// SetNull makes the Nullable explicitly null.
+func (n *Nullable[T]) SetNull() {
+	*n = NewNull[T]()
+}
+
+// SetUndefined resets the Nullable to Undefined.
+func (n *Nullable[T]) SetUndefined() {
+	*n = NewUndefined[T]()
+}
+
+// ApplyTo writes the Nullable into target: Undefined leaves target untouched,
+// Null resets it to its zero value and a value overwrites it.
+func (n Nullable[T]) ApplyTo(target *T) {
+	if n.IsUndefined() {
+		return
+	}
+	var zero T
+	*target = n.OrElse(zero)
+}
+
+// MarshalJSON implements json.Marshaler. Both Undefined and Null are encoded as
+// null, see MarshalPatch to leave Undefined fields out.
+func (n Nullable[T]) MarshalJSON() ([]byte, error) {
+	if n.IsValue() {
+		return json.Marshal(n.value)
+	}
+	return json.Marshal(nil)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It is only called for keys present
+// in the document so a missing key stays Undefined while null produces Null.
+func (n *Nullable[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		n.SetNull()
+		return nil
+	}
+
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	n.Set(value)
+	return nil
+}
+
+// patchState exposes the state of a Nullable regardless of its type parameter.
+func (n Nullable[T]) patchState() (defined bool, null bool, value any) {
+	if n.IsValue() {
+		return true, false, n.value
+	}
+	return n.IsDefined(), n.IsNull(), nil
+}
@@ -0,0 +1,21 @@
+//go:build go1.24
+
+package fx
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNullableOmitZero(t *testing.T) {
+	type payload struct {
+		Name Nullable[string] `json:"name,omitzero"`
+		Age  Nullable[int]    `json:"age,omitzero"`
+	}
+
+	out, err := json.Marshal(payload{Name: NewNull[string]()})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"name":null}`, string(out))
+}
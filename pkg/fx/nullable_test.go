@@ -0,0 +1,241 @@
+package fx
+
+import (
+	"encoding/json"
+	"testing"
+
+	fxerror "github.com/fredsh/go-fxtend/pkg/fx-error"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNullableJSON(t *testing.T) {
+	type payload struct {
+		Name Nullable[string] `json:"name"`
+		Age  Nullable[int]    `json:"age"`
+	}
+
+	cases := []struct {
+		name  string
+		input string
+		want  payload
+		out   string
+	}{
+		{
+			name:  "missing fields produce Undefined",
+			input: `{}`,
+			want:  payload{},
+			out:   `{"name":null,"age":null}`,
+		},
+		{
+			name:  "explicit null produce Null",
+			input: `{"name":null}`,
+			want:  payload{Name: NewNull[string]()},
+			out:   `{"name":null,"age":null}`,
+		},
+		{
+			name:  "values produce Value",
+			input: `{"name":"bob","age":0}`,
+			want:  payload{Name: NewValue("bob"), Age: NewValue(0)},
+			out:   `{"name":"bob","age":0}`,
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var got payload
+			require.NoError(t, json.Unmarshal([]byte(tt.input), &got))
+			require.Equal(t, tt.want, got)
+
+			out, err := json.Marshal(got)
+			require.NoError(t, err)
+			require.JSONEq(t, tt.out, string(out))
+		})
+	}
+
+	var invalid payload
+	require.Error(t, json.Unmarshal([]byte(`{"age":"x"}`), &invalid))
+}
+
+func TestMarshalPatch(t *testing.T) {
+	type Audit struct {
+		Reason Nullable[string] `json:"reason,omitempty"`
+		Name   Nullable[string] `json:"name,omitempty"`
+	}
+	type payload struct {
+		Name    Nullable[string] `json:"name,omitempty"`
+		Age     Nullable[int]    `json:"age,omitempty"`
+		Email   Nullable[string] `json:"email"`
+		Comment string           `json:"comment,omitempty"`
+		*Audit
+	}
+
+	cases := []struct {
+		name  string
+		input any
+		want  string
+	}{
+		{
+			name:  "Undefined omitempty fields are left out",
+			input: payload{Age: NewValue(0)},
+			want:  `{"age":0,"email":null}`,
+		},
+		{
+			name:  "Null fields are kept as null",
+			input: &payload{Name: NewNull[string](), Email: NewValue("a@b.c")},
+			want:  `{"name":null,"email":"a@b.c"}`,
+		},
+		{
+			name:  "embedded fields are left out unless shadowed",
+			input: payload{Name: NewValue("bob"), Audit: &Audit{Name: NewValue("hidden")}},
+			want:  `{"name":"bob","email":null}`,
+		},
+		{
+			name:  "set embedded fields are kept",
+			input: payload{Audit: &Audit{Reason: NewNull[string]()}},
+			want:  `{"email":null,"reason":null}`,
+		},
+		{
+			name:  "values other than structs are encoded as is",
+			input: map[string]Nullable[int]{"a": NewUndefined[int]()},
+			want:  `{"a":null}`,
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := MarshalPatch(tt.input)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, string(out), "keys keep the order of json.Marshal")
+		})
+	}
+
+	omitempty, err := json.Marshal(struct {
+		N Nullable[string] `json:"n,omitempty"`
+	}{})
+	require.NoError(t, err)
+	require.Equal(t, `{"n":null}`, string(omitempty), "json.Marshal alone cannot leave a struct out")
+}
+
+func TestNullableState(t *testing.T) {
+	undefined := NewUndefined[int]()
+	require.True(t, undefined.IsUndefined())
+	require.True(t, undefined.IsZero())
+	require.Equal(t, Nullable[int]{}, undefined)
+	require.False(t, NewNull[int]() == NewValue(0), "Null and a zero value are told apart")
+	require.False(t, undefined.IsNull())
+	require.False(t, undefined.IsValue())
+
+	null := NewNull[int]()
+	require.True(t, null.IsDefined())
+	require.True(t, null.IsNull())
+	require.True(t, null.ToMaybe().IsNone())
+	_, err := null.Get()
+	require.True(t, fxerror.IsNotFound(err))
+	_, err = NewNone[int]().Get()
+	require.True(t, fxerror.IsNotFound(err))
+
+	value := NewValue(3)
+	require.True(t, value.IsValue())
+	require.Equal(t, 3, value.OrElse(1))
+	require.Equal(t, NewSome(3), value.ToMaybe())
+
+	target := 5
+	undefined.ApplyTo(&target)
+	require.Equal(t, 5, target)
+	value.ApplyTo(&target)
+	require.Equal(t, 3, target)
+	null.ApplyTo(&target)
+	require.Equal(t, 0, target)
+}
+
+func TestApplyPatch(t *testing.T) {
+	type user struct {
+		Name     string
+		Nickname *string
+		Age      Maybe[int]
+		Email    string
+		private  string
+	}
+	type userPatch struct {
+		Name     Nullable[string]
+		Nickname Nullable[string]
+		Age      Nullable[int]
+		Mail     Nullable[string] `patch:"Email"`
+		Ignored  Nullable[string] `patch:"-"`
+		Comment  string
+	}
+	nick := "bobby"
+	base := func() user {
+		return user{Name: "bob", Nickname: &nick, Age: NewSome(30), Email: "bob@example.com", private: "x"}
+	}
+
+	cases := []struct {
+		name    string
+		patch   any
+		want    func() user
+		wantErr bool
+	}{
+		{
+			name:  "empty patch leaves the target untouched",
+			patch: userPatch{Comment: "noop"},
+			want:  base,
+		},
+		{
+			name:  "null fields reset the target fields",
+			patch: userPatch{Nickname: NewNull[string](), Age: NewNull[int](), Mail: NewNull[string]()},
+			want: func() user {
+				u := base()
+				u.Nickname, u.Age, u.Email = nil, NewNone[int](), ""
+				return u
+			},
+		},
+		{
+			name:  "values are assigned to plain, pointer and Maybe fields",
+			patch: &userPatch{Name: NewValue("alice"), Nickname: NewValue("al"), Age: NewValue(31), Ignored: NewValue("x")},
+			want: func() user {
+				u := base()
+				al := "al"
+				u.Name, u.Nickname, u.Age = "alice", &al, NewSome(31)
+				return u
+			},
+		},
+		{
+			name: "unknown target field produce an error",
+			patch: struct {
+				Unknown Nullable[string]
+			}{Unknown: NewValue("x")},
+			wantErr: true,
+		},
+		{
+			name: "incompatible type produce an error",
+			patch: struct {
+				Name Nullable[string]
+				Age  Nullable[string]
+			}{Name: NewValue("alice"), Age: NewValue("x")},
+			wantErr: true,
+		},
+		{
+			name: "unexported target field produce an error",
+			patch: struct {
+				Private Nullable[string] `patch:"private"`
+			}{Private: NewValue("x")},
+			wantErr: true,
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			got := base()
+			err := ApplyPatch(&got, tt.patch)
+			if tt.wantErr {
+				require.Error(t, err)
+				require.Equal(t, base(), got, "a failing patch leaves the target untouched")
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want(), got)
+		})
+	}
+}
+
+func TestApplyPatchNilTarget(t *testing.T) {
+	var target *struct{ Name string }
+	require.Error(t, ApplyPatch(target, struct{ Name Nullable[string] }{Name: NewValue("x")}))
+}
@@ -1,7 +1,23 @@
 package fx
 
+import (
+	"fmt"
+
+	fxerror "github.com/fredsh/go-fxtend/pkg/fx-error"
+)
+
 type OptionEnhancer[T any] func(T) T
 
+// OptionEnhancerE is an OptionEnhancer which can reject the value it is given.
+type OptionEnhancerE[T any] func(T) (T, error)
+
+// LiftOption turns an OptionEnhancer into an OptionEnhancerE which never fails.
+func LiftOption[T any](o OptionEnhancer[T]) OptionEnhancerE[T] {
+	return func(options T) (T, error) {
+		return o(options), nil
+	}
+}
+
 func OptionBuilder[T any](initial func() T, opts ...OptionEnhancer[T]) T {
 	options := initial()
 	for _, o := range opts {
@@ -10,8 +26,47 @@ func OptionBuilder[T any](initial func() T, opts ...OptionEnhancer[T]) T {
 	return options
 }
 
+// OptionBuilderE builds options like OptionBuilder with options which can fail.
+// A failing option leaves the options unchanged and the next ones still run so
+// that every error is reported. The built options are then given to validate,
+// which may be nil. All the errors are returned as an fxerror.MultiError.
+func OptionBuilderE[T any](initial func() T, validate func(T) error, opts ...OptionEnhancerE[T]) Result[T] {
+	options := initial()
+	var errs []error
+	for i, o := range opts {
+		options, errs = applyOptionE(options, o, i, errs)
+	}
+	return validateOptions(options, validate, errs)
+}
+
+func applyOptionE[T any](options T, o OptionEnhancerE[T], index int, errs []error) (T, []error) {
+	enhanced, err := o(options)
+	if err != nil {
+		return options, append(errs, fmt.Errorf("option %d: %w", index, err))
+	}
+	return enhanced, errs
+}
+
+func validateOptions[T any](options T, validate func(T) error, errs []error) Result[T] {
+	if validate != nil {
+		if err := validate(options); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := fxerror.NewMultiError(errs...); err != nil {
+		return NewFailure[T](err)
+	}
+	return NewSuccess(options)
+}
+
 type OptionBuilderFluent[T any] struct {
 	options T
+	applied int
+	errs    []error
+	sources map[string]string
+	tracing bool
+	traces  []OptionTrace
+	copy    func(T) T // Deep-copy hook inherited from OptionBuilderImmutable.
 }
 
 func NewConfigBuilderFluent[T any](defaultConfig T) OptionBuilderFluent[T] {
@@ -24,7 +79,35 @@ func (b *OptionBuilderFluent[T]) Config() T {
 	return b.options
 }
 
+func (b *OptionBuilderFluent[T]) clone() T {
+	if b.copy == nil {
+		return deepCopy(b.options)
+	}
+	return b.copy(b.options)
+}
+
 func (b *OptionBuilderFluent[T]) With(o OptionEnhancer[T]) *OptionBuilderFluent[T] {
+	if b.tracing {
+		defer b.trace(funcName(o), b.clone(), len(b.errs))
+	}
 	b.options = o(b.options)
+	b.applied++
 	return b
 }
+
+// WithE applies an option which can fail, its error is kept for Validate and
+// the options are left unchanged.
+func (b *OptionBuilderFluent[T]) WithE(o OptionEnhancerE[T]) *OptionBuilderFluent[T] {
+	if b.tracing {
+		defer b.trace(funcName(o), b.clone(), len(b.errs))
+	}
+	b.options, b.errs = applyOptionE(b.options, o, b.applied, b.errs)
+	b.applied++
+	return b
+}
+
+// Validate gives the options to validate, which may be nil, and returns them or
+// every error reported by WithE and validate, see OptionBuilderE.
+func (b *OptionBuilderFluent[T]) Validate(validate func(T) error) Result[T] {
+	return validateOptions(b.options, validate, append([]error(nil), b.errs...))
+}
@@ -0,0 +1,56 @@
+package fx
+
+// OptionBuilderImmutable is a copy-on-write counterpart of OptionBuilderFluent:
+// With never modifies the builder it is called on and returns a new one, so a
+// base builder can be forked into several variants.
+//
+// Options receive a deep copy of the config so that configs holding maps or
+// slices do not share them across variants. By default the maps and slices of
+// the exported fields are copied, WithDeepCopy replaces it for configs sharing
+// state through pointers or unexported fields.
+type OptionBuilderImmutable[T any] struct {
+	options T
+	copy    func(T) T
+}
+
+// NewConfigBuilderImmutable creates an OptionBuilderImmutable starting from
+// defaultConfig.
+func NewConfigBuilderImmutable[T any](defaultConfig T) OptionBuilderImmutable[T] {
+	return OptionBuilderImmutable[T]{
+		options: defaultConfig,
+	}
+}
+
+// WithDeepCopy returns a builder copying the config with deepCopy before giving
+// it to an option or returning it from Config. The hook is kept by Fluent.
+func (b OptionBuilderImmutable[T]) WithDeepCopy(deepCopy func(T) T) OptionBuilderImmutable[T] {
+	b.copy = deepCopy
+	return b
+}
+
+// Config returns a copy of the config.
+func (b OptionBuilderImmutable[T]) Config() T {
+	return b.clone()
+}
+
+// With returns a new builder holding the config enhanced by o.
+func (b OptionBuilderImmutable[T]) With(o OptionEnhancer[T]) OptionBuilderImmutable[T] {
+	b.options = o(b.clone())
+	return b
+}
+
+// Fluent returns a mutable OptionBuilderFluent starting from a copy of the
+// config, to finish building a variant with WithE, WithSource or tracing. The
+// fluent builder copies the config with the same hook when applying sources.
+func (b OptionBuilderImmutable[T]) Fluent() *OptionBuilderFluent[T] {
+	fluent := NewConfigBuilderFluent(b.clone())
+	fluent.copy = b.copy
+	return &fluent
+}
+
+func (b OptionBuilderImmutable[T]) clone() T {
+	if b.copy == nil {
+		return deepCopy(b.options)
+	}
+	return b.copy(b.options)
+}
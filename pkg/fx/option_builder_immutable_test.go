@@ -0,0 +1,79 @@
+package fx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type tenantConfig struct {
+	Name   string
+	Labels map[string]string
+}
+
+func copyTenantConfig(c tenantConfig) tenantConfig {
+	labels := make(map[string]string, len(c.Labels))
+	for k, v := range c.Labels {
+		labels[k] = v
+	}
+	c.Labels = labels
+	return c
+}
+
+func withLabel(key, value string) OptionEnhancer[tenantConfig] {
+	return func(c tenantConfig) tenantConfig {
+		c.Labels[key] = value
+		return c
+	}
+}
+
+func withTenantName(name string) OptionEnhancer[tenantConfig] {
+	return func(c tenantConfig) tenantConfig {
+		c.Name = name
+		return c
+	}
+}
+
+func TestOptionBuilderImmutable(t *testing.T) {
+	base := NewConfigBuilderImmutable(tenantConfig{Name: "base", Labels: map[string]string{"env": "prod"}}).
+		WithDeepCopy(copyTenantConfig)
+
+	acme := base.With(withTenantName("acme")).With(withLabel("tier", "gold"))
+	globex := base.With(withTenantName("globex")).With(withLabel("tier", "silver"))
+
+	require.Equal(t, tenantConfig{Name: "base", Labels: map[string]string{"env": "prod"}}, base.Config())
+	require.Equal(t, tenantConfig{Name: "acme", Labels: map[string]string{"env": "prod", "tier": "gold"}}, acme.Config())
+	require.Equal(t, tenantConfig{Name: "globex", Labels: map[string]string{"env": "prod", "tier": "silver"}}, globex.Config())
+
+	config := acme.Config()
+	config.Labels["tier"] = "changed"
+	require.Equal(t, "gold", acme.Config().Labels["tier"], "Config returns a copy")
+
+	fluent := acme.Fluent().With(withLabel("region", "eu"))
+	require.Equal(t, "eu", fluent.Config().Labels["region"])
+	require.NotContains(t, acme.Config().Labels, "region")
+
+	shallow := NewConfigBuilderImmutable(tenantConfig{Name: "base"})
+	require.Equal(t, "other", shallow.With(withTenantName("other")).Config().Name)
+	require.Equal(t, "base", shallow.Config().Name)
+
+	require.Nil(t, NewConfigBuilderImmutable[error](nil).Config(), "nil interface configs are supported")
+	nilFluent := NewConfigBuilderFluent[any](nil)
+	require.Nil(t, nilFluent.WithSource(ConfigSource[any]{Name: "noop", Load: func(*any) ([]string, error) { return nil, nil }}).Config())
+
+	shared := NewConfigBuilderImmutable(tenantConfig{Name: "base", Labels: map[string]string{"env": "prod"}})
+	variant := shared.With(withLabel("tier", "gold"))
+	require.Equal(t, map[string]string{"env": "prod"}, shared.Config().Labels, "maps are deep-copied without a hook")
+	require.Equal(t, map[string]string{"env": "prod", "tier": "gold"}, variant.Config().Labels)
+
+	copies := 0
+	counted := base.WithDeepCopy(func(c tenantConfig) tenantConfig {
+		copies++
+		return copyTenantConfig(c)
+	})
+	source := ConfigSource[tenantConfig]{Name: "test", Load: func(c *tenantConfig) ([]string, error) { return nil, nil }}
+	fluent = counted.Fluent()
+	copies = 0
+	fluent.WithSource(source)
+	require.Equal(t, 1, copies, "Fluent keeps the deep-copy hook")
+}
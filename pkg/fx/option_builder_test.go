@@ -0,0 +1,103 @@
+package fx
+
+import (
+	"errors"
+	"testing"
+
+	fxerror "github.com/fredsh/go-fxtend/pkg/fx-error"
+	"github.com/stretchr/testify/require"
+)
+
+type serverConfig struct {
+	Host string
+	Port int
+}
+
+func defaultServerConfig() serverConfig {
+	return serverConfig{Host: "localhost", Port: 80}
+}
+
+func withPort(port int) OptionEnhancerE[serverConfig] {
+	return func(c serverConfig) (serverConfig, error) {
+		if port <= 0 || port > 65535 {
+			return c, fxerror.New(fxerror.ErrInvalidArgument, "port out of range")
+		}
+		c.Port = port
+		return c, nil
+	}
+}
+
+func withHost(host string) OptionEnhancer[serverConfig] {
+	return func(c serverConfig) serverConfig {
+		c.Host = host
+		return c
+	}
+}
+
+var errEmptyHost = errors.New("host is required")
+
+func validateServerConfig(c serverConfig) error {
+	if c.Host == "" {
+		return errEmptyHost
+	}
+	return nil
+}
+
+func TestOptionBuilderE(t *testing.T) {
+	cases := []struct {
+		name     string
+		opts     []OptionEnhancerE[serverConfig]
+		validate func(serverConfig) error
+		want     serverConfig
+		wantErrs int
+	}{
+		{
+			name: "no option produce the initial config",
+			want: defaultServerConfig(),
+		},
+		{
+			name:     "valid options are applied",
+			opts:     []OptionEnhancerE[serverConfig]{withPort(8080), LiftOption(withHost("example.com"))},
+			validate: validateServerConfig,
+			want:     serverConfig{Host: "example.com", Port: 8080},
+		},
+		{
+			name:     "every failure is reported",
+			opts:     []OptionEnhancerE[serverConfig]{withPort(0), LiftOption(withHost("")), withPort(70000)},
+			validate: validateServerConfig,
+			wantErrs: 3,
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			res := OptionBuilderE(defaultServerConfig, tt.validate, tt.opts...)
+			if tt.wantErrs > 0 {
+				var multi *fxerror.MultiError
+				require.ErrorAs(t, res.AsError(), &multi)
+				require.Len(t, multi.Errors, tt.wantErrs)
+				return
+			}
+			require.NoError(t, res.AsError())
+			require.Equal(t, tt.want, res.Unwrap())
+		})
+	}
+}
+
+func TestOptionBuilderFluentWithE(t *testing.T) {
+	b := NewConfigBuilderFluent(defaultServerConfig())
+	res := b.With(withHost("")).WithE(withPort(-1)).WithE(withPort(443)).Validate(validateServerConfig)
+
+	var multi *fxerror.MultiError
+	require.ErrorAs(t, res.AsError(), &multi)
+	require.Len(t, multi.Errors, 2)
+	require.True(t, fxerror.IsInvalidArgument(multi.Errors[0]))
+	require.ErrorContains(t, multi.Errors[0], "option 1")
+	require.ErrorIs(t, multi.Errors[1], errEmptyHost)
+	require.Equal(t, serverConfig{Port: 443}, b.Config())
+
+	b.With(withHost("example.com"))
+	require.Len(t, b.Validate(validateServerConfig).AsError().(*fxerror.MultiError).Errors, 1, "option errors are kept")
+
+	other := NewConfigBuilderFluent(serverConfig{Host: "h", Port: 1})
+	require.Equal(t, serverConfig{Host: "h", Port: 1}, other.Validate(nil).Unwrap())
+}
@@ -0,0 +1,143 @@
+package fx
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// FieldChange is the change of a single config field made by an option.
+type FieldChange struct {
+	Path string // Path of the field, such as "Server.Port", empty if T is not a struct.
+	Old  any
+	New  any
+}
+
+// OptionTrace records what one option applied to an OptionBuilderFluent did.
+type OptionTrace struct {
+	Name    string        // Name of the option function or of the ConfigSource.
+	Changes []FieldChange // Fields changed by the option.
+	Err     error         // Error reported by the option, if any.
+}
+
+// EnableTrace makes the builder record an OptionTrace for every option applied
+// from now on. Tracing is off by default and costs nothing then.
+//
+// Fields are compared with reflect.DeepEqual against a deep copy of the config
+// taken before the option ran, so options mutating maps or slices in place are
+// traced too. The copy shares pointers, unless the builder was made by Fluent
+// from an OptionBuilderImmutable with a WithDeepCopy hook. Unexported fields are
+// not compared.
+func (b *OptionBuilderFluent[T]) EnableTrace() *OptionBuilderFluent[T] {
+	b.tracing = true
+	return b
+}
+
+// WithNamed applies o like With, recording it under name when tracing.
+func (b *OptionBuilderFluent[T]) WithNamed(name string, o OptionEnhancer[T]) *OptionBuilderFluent[T] {
+	if b.tracing {
+		defer b.trace(name, b.clone(), len(b.errs))
+	}
+	b.options = o(b.options)
+	b.applied++
+	return b
+}
+
+// Trace returns the options recorded since EnableTrace, in the order they were
+// applied.
+func (b *OptionBuilderFluent[T]) Trace() []OptionTrace {
+	return append([]OptionTrace(nil), b.traces...)
+}
+
+// TraceString renders the trace, one option per line followed by the fields it
+// changed, for startup logs.
+func (b *OptionBuilderFluent[T]) TraceString() string {
+	var sb strings.Builder
+	for i, trace := range b.traces {
+		fmt.Fprintf(&sb, "%d. %s", i+1, trace.Name)
+		switch {
+		case trace.Err != nil:
+			fmt.Fprintf(&sb, ": failed: %v\n", trace.Err)
+		case len(trace.Changes) == 0:
+			sb.WriteString(": no change\n")
+		default:
+			sb.WriteString("\n")
+		}
+		for _, change := range trace.Changes {
+			path := change.Path
+			if path == "" {
+				path = "(value)"
+			}
+			fmt.Fprintf(&sb, "   %s: %s -> %s\n", path, traceValue(change.Old), traceValue(change.New))
+		}
+	}
+	return sb.String()
+}
+
+// trace is deferred by the options applied while tracing, with a copy of the
+// config and the number of errors from before they ran.
+func (b *OptionBuilderFluent[T]) trace(name string, before T, errCount int) {
+	trace := OptionTrace{
+		Name:    name,
+		Changes: diffFields(reflect.ValueOf(&before).Elem(), reflect.ValueOf(&b.options).Elem(), ""),
+	}
+	if len(b.errs) > errCount {
+		trace.Err = b.errs[len(b.errs)-1]
+	}
+	b.traces = append(b.traces, trace)
+}
+
+// diffFields lists the fields differing between before and after. It looks into
+// the top-level struct and the nested structs made only of exported fields,
+// others such as Maybe or time.Time are compared as a whole.
+func diffFields(before, after reflect.Value, prefix string) []FieldChange {
+	if before.Kind() != reflect.Struct || (prefix != "" && !allExported(before.Type())) {
+		if reflect.DeepEqual(before.Interface(), after.Interface()) {
+			return nil
+		}
+		return []FieldChange{{Path: strings.TrimSuffix(prefix, "."), Old: before.Interface(), New: after.Interface()}}
+	}
+	var changes []FieldChange
+	for i := 0; i < before.NumField(); i++ {
+		sf := before.Type().Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		changes = append(changes, diffFields(before.Field(i), after.Field(i), prefix+sf.Name+".")...)
+	}
+	return changes
+}
+
+// allExported tells whether every field of the struct type t is exported.
+func allExported(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if !t.Field(i).IsExported() {
+			return false
+		}
+	}
+	return true
+}
+
+// funcName returns the short name of the function f, such as "config.withPort".
+// Anonymous functions keep their ".funcN" suffix, such as "config.New.func1", so
+// that several of them declared in the same function can be told apart.
+func funcName(f any) string {
+	fn := runtime.FuncForPC(reflect.ValueOf(f).Pointer())
+	if fn == nil {
+		return "unknown"
+	}
+	name := fn.Name()
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	return name
+}
+
+func traceValue(v any) string {
+	if s, ok := v.(string); ok {
+		return strconv.Quote(s)
+	}
+	return fmt.Sprintf("%v", v)
+}
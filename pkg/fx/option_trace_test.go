@@ -0,0 +1,73 @@
+package fx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptionBuilderTrace(t *testing.T) {
+	t.Setenv("TRACE_PORT", "3000")
+
+	b := NewConfigBuilderFluent(appConfig{Name: "app", Port: 80})
+	b.With(func(c appConfig) appConfig { return c })
+	require.Empty(t, b.Trace(), "tracing is disabled by default")
+
+	b.EnableTrace().
+		With(func(c appConfig) appConfig {
+			c.Name = "traced"
+			c.Limits.Burst = 3
+			return c
+		}).
+		WithNamed("timeout", func(c appConfig) appConfig {
+			c.Timeout = NewSome(time.Second)
+			return c
+		}).
+		WithE(func(c appConfig) (appConfig, error) { return c, errEmptyHost }).
+		WithSource(EnvSource[appConfig]("TRACE_")).
+		WithNamed("noop", func(c appConfig) appConfig { return c })
+
+	trace := b.Trace()
+	require.Len(t, trace, 5)
+	require.Equal(t, "fx.TestOptionBuilderTrace.func2", trace[0].Name)
+	require.Equal(t, "fx.TestOptionBuilderTrace.func4", trace[2].Name, "anonymous options are told apart")
+	require.Equal(t, []FieldChange{
+		{Path: "Name", Old: "app", New: "traced"},
+		{Path: "Limits.Burst", Old: 0, New: 3},
+	}, trace[0].Changes)
+	require.Equal(t, []FieldChange{{Path: "Timeout", Old: NewNone[time.Duration](), New: NewSome(time.Second)}}, trace[1].Changes)
+	require.ErrorIs(t, trace[2].Err, errEmptyHost)
+	require.Equal(t, []FieldChange{{Path: "Port", Old: 80, New: 3000}}, trace[3].Changes)
+
+	require.Equal(t, `1. fx.TestOptionBuilderTrace.func2
+   Name: "app" -> "traced"
+   Limits.Burst: 0 -> 3
+2. timeout
+   Timeout: None -> Some(1s)
+3. fx.TestOptionBuilderTrace.func4: failed: option 3: host is required
+4. env
+   Port: 80 -> 3000
+5. noop: no change
+`, b.TraceString())
+}
+
+func TestOptionBuilderTraceInPlace(t *testing.T) {
+	b := NewConfigBuilderFluent(appConfig{Labels: map[string]string{"env": "prod"}})
+	b.EnableTrace().WithNamed("team", func(c appConfig) appConfig {
+		c.Labels["team"] = "x"
+		return c
+	})
+	require.Equal(t, []FieldChange{{
+		Path: "Labels",
+		Old:  map[string]string{"env": "prod"},
+		New:  map[string]string{"env": "prod", "team": "x"},
+	}}, b.Trace()[0].Changes, "maps changed in place are traced")
+}
+
+func TestOptionBuilderTraceScalar(t *testing.T) {
+	b := NewConfigBuilderFluent(1)
+	b.EnableTrace().With(func(i int) int { return i + 1 })
+	require.Equal(t, []FieldChange{{Old: 1, New: 2}}, b.Trace()[0].Changes)
+	require.Equal(t, "1. fx.TestOptionBuilderTraceScalar.func1\n   (value): 1 -> 2\n", b.TraceString())
+}
@@ -0,0 +1,246 @@
+package fx
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// patchField is implemented by Nullable whatever its type parameter.
+type patchField interface {
+	patchState() (defined bool, null bool, value any)
+}
+
+// patchSetter is implemented by *Maybe whatever its type parameter.
+type patchSetter interface {
+	patchSome(value any) bool
+}
+
+func (m *Maybe[T]) patchSome(value any) bool {
+	v, ok := value.(T)
+	if !ok {
+		return false
+	}
+	*m = NewSome(v)
+	return true
+}
+
+// ApplyPatch copies every Nullable field of patch onto the field with the same
+// name in target. The target field can be overridden with the `patch:"Name"` tag,
+// `patch:"-"` skips the field, other patch fields are ignored.
+//
+// Undefined fields leave the target untouched, Null fields reset it to its zero
+// value (None for a Maybe, nil for a pointer) and values are assigned to target
+// fields of type T, *T or Maybe[T].
+//
+// The patch is applied to a copy of target which is only stored once every
+// field was applied, so target is left untouched when an error is returned.
+func ApplyPatch[T any, P any](target *T, patch P) error {
+	if target == nil {
+		return fmt.Errorf("patch target must not be nil")
+	}
+	patched := *target
+	tv := reflect.ValueOf(&patched).Elem()
+	if tv.Kind() != reflect.Struct {
+		return fmt.Errorf("patch target must be a struct, got %s", tv.Type())
+	}
+	pv := reflect.ValueOf(patch)
+	if pv.Kind() == reflect.Pointer {
+		pv = pv.Elem()
+	}
+	if pv.Kind() != reflect.Struct {
+		return fmt.Errorf("patch must be a struct, got %T", patch)
+	}
+
+	pt := pv.Type()
+	for i := 0; i < pt.NumField(); i++ {
+		sf := pt.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		field, ok := pv.Field(i).Interface().(patchField)
+		if !ok {
+			continue
+		}
+		name := sf.Name
+		if tag := sf.Tag.Get("patch"); tag == "-" {
+			continue
+		} else if tag != "" {
+			name = tag
+		}
+
+		defined, null, value := field.patchState()
+		if !defined {
+			continue
+		}
+		dst := tv.FieldByName(name)
+		if !dst.IsValid() || !dst.CanSet() {
+			return fmt.Errorf("patch field %s: no settable field %s in %s", sf.Name, name, tv.Type())
+		}
+		if null {
+			dst.Set(reflect.Zero(dst.Type()))
+			continue
+		}
+		if err := assignPatchValue(dst, value); err != nil {
+			return fmt.Errorf("patch field %s: %w", sf.Name, err)
+		}
+	}
+	*target = patched
+	return nil
+}
+
+func assignPatchValue(dst reflect.Value, value any) error {
+	vv := reflect.ValueOf(value)
+	if !vv.IsValid() {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+
+	switch {
+	case vv.Type().AssignableTo(dst.Type()):
+		dst.Set(vv)
+	case dst.Kind() == reflect.Pointer && vv.Type().AssignableTo(dst.Type().Elem()):
+		ptr := reflect.New(dst.Type().Elem())
+		ptr.Elem().Set(vv)
+		dst.Set(ptr)
+	default:
+		setter, ok := dst.Addr().Interface().(patchSetter)
+		if !ok || !setter.patchSome(value) {
+			return fmt.Errorf("cannot assign %s to %s", vv.Type(), dst.Type())
+		}
+	}
+	return nil
+}
+
+var jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+// MarshalPatch encodes patch as JSON like json.Marshal but leaves out its
+// Undefined Nullable fields tagged `omitempty`, including those of embedded
+// structs, so that a PATCH body tells absent fields from null ones. Before Go
+// 1.24 and its `omitzero` tag option, encoding/json encodes them as null.
+// Values which are not structs, or which implement json.Marshaler, are encoded
+// as is.
+func MarshalPatch(patch any) ([]byte, error) {
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return nil, err
+	}
+	v := reflect.ValueOf(patch)
+	for v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct || reflect.PointerTo(v.Type()).Implements(jsonMarshalerType) {
+		return data, nil
+	}
+	omitted := undefinedKeys(v)
+	if len(omitted) == 0 {
+		return data, nil
+	}
+	return dropKeys(data, omitted)
+}
+
+// undefinedKeys returns the JSON keys of the Undefined Nullable fields of the
+// struct v tagged `omitempty` or `omitzero`. Like encoding/json, the fields of
+// untagged embedded structs are promoted unless a field of v has the same key.
+func undefinedKeys(v reflect.Value) map[string]bool {
+	undefined, claimed := map[string]bool{}, map[string]bool{}
+	var embedded []reflect.Value
+	vt := v.Type()
+	for i := 0; i < vt.NumField(); i++ {
+		sf := vt.Field(i)
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		fv := v.Field(i)
+		if sf.Anonymous && name == "" {
+			if fv.Kind() == reflect.Pointer {
+				if fv.IsNil() {
+					continue
+				}
+				fv = fv.Elem()
+			}
+			if fv.Kind() == reflect.Struct {
+				embedded = append(embedded, fv)
+				continue
+			}
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		claimed[name] = true
+		if !fv.CanInterface() {
+			continue
+		}
+		field, ok := fv.Interface().(patchField)
+		if !ok || !(hasTagOption(opts, "omitempty") || hasTagOption(opts, "omitzero")) {
+			continue
+		}
+		if defined, _, _ := field.patchState(); !defined {
+			undefined[name] = true
+		}
+	}
+	for _, e := range embedded {
+		for key := range undefinedKeys(e) {
+			if !claimed[key] {
+				undefined[key] = true
+			}
+		}
+	}
+	return undefined
+}
+
+// hasTagOption reports whether the comma separated tag options opts hold option.
+func hasTagOption(opts string, option string) bool {
+	for opts != "" {
+		var opt string
+		opt, opts, _ = strings.Cut(opts, ",")
+		if opt == option {
+			return true
+		}
+	}
+	return false
+}
+
+// dropKeys removes keys from the JSON object data, keeping the order of the
+// other members.
+func dropKeys(data []byte, keys map[string]bool) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, _ := token.(string)
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, err
+		}
+		if keys[key] {
+			continue
+		}
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		encodedKey, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(encodedKey)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
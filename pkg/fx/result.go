@@ -2,6 +2,8 @@ package fx
 
 import (
 	"context"
+
+	fxerror "github.com/fredsh/go-fxtend/pkg/fx-error"
 )
 
 // Result is a type representing either a success value or an error
@@ -15,9 +17,19 @@ func NewSuccess[T any](value T) Result[T] {
 	return Result[T]{value: value}
 }
 
-// NewFailure creates a new Result with an error
+// NewFailure creates a new Result with an error. When stack capture is enabled
+// with fxerror.SetCaptureStacks, the error is wrapped with the stack of the caller.
 func NewFailure[T any](err error) Result[T] {
-	return Result[T]{err: err}
+	return Result[T]{err: captureStack(err)}
+}
+
+// captureStack wraps err with the stack of the caller of the fx function calling
+// it, if stack capture is enabled.
+func captureStack(err error) error {
+	if err == nil || !fxerror.CaptureStacks() {
+		return err
+	}
+	return fxerror.WithStackSkip(err, 2)
 }
 
 // IsSuccess returns true if the result is a success
@@ -84,7 +96,7 @@ func FlatMapErr[T any, U any](r Result[T], f func(T) (U, error)) Result[U] {
 	res, err := f(r.Unwrap())
 	return Result[U]{
 		value: res,
-		err:   err,
+		err:   captureStack(err),
 	}
 }
 
@@ -137,6 +149,6 @@ func FlatMapErrCtx[T any, U any](ctx context.Context, r Result[T], f func(contex
 	res, err := f(ctx, r.Unwrap())
 	return Result[U]{
 		value: res,
-		err:   err,
+		err:   captureStack(err),
 	}
 }
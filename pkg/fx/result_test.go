@@ -0,0 +1,39 @@
+package fx
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	fxerror "github.com/fredsh/go-fxtend/pkg/fx-error"
+	"github.com/stretchr/testify/require"
+)
+
+func failingStage(int) (string, error) {
+	return "", errors.New("stage failed")
+}
+
+func TestNewFailureStackCapture(t *testing.T) {
+	var stackErr *fxerror.StackError
+
+	disabled := NewFailure[int](errors.New("boom"))
+	require.False(t, errors.As(disabled.AsError(), &stackErr))
+
+	fxerror.SetCaptureStacks(true)
+	defer fxerror.SetCaptureStacks(false)
+
+	failure := NewFailure[int](errors.New("boom"))
+	require.ErrorAs(t, failure.AsError(), &stackErr)
+	require.True(t, strings.HasSuffix(stackErr.StackTrace()[0].Function, "fx.TestNewFailureStackCapture"))
+
+	// the stack recorded by the first failing stage is kept through later stages
+	res := Map(FlatMapErr(NewSuccess(1), failingStage), strings.ToUpper)
+	require.ErrorAs(t, res.AsError(), &stackErr)
+	require.True(t, strings.HasSuffix(stackErr.StackTrace()[0].Function, "fx.TestNewFailureStackCapture"))
+	require.EqualError(t, res.AsError(), "stage failed")
+
+	detailed := fmt.Sprintf("%+v", res)
+	require.True(t, strings.HasPrefix(detailed, "Err[string](*fxerror.StackError: stage failed\n"))
+	require.Contains(t, detailed, "result_test.go:")
+}
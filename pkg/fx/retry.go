@@ -0,0 +1,122 @@
+package fx
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+
+	fxerror "github.com/fredsh/go-fxtend/pkg/fx-error"
+)
+
+// RetryPolicy configures Retry. The wait before the n-th retry is
+// InitialInterval * Multiplier^(n-1), capped at MaxInterval and randomized by
+// ±Jitter percent.
+type RetryPolicy struct {
+	MaxAttempts     int              // Maximum number of calls, 0 means unlimited.
+	InitialInterval time.Duration    // Wait before the first retry.
+	MaxInterval     time.Duration    // Upper bound of the wait, 0 means unbounded.
+	Multiplier      float64          // Growth factor of the wait, values below 1 keep it constant.
+	Jitter          float64          // Randomization factor of the wait, between 0 and 1.
+	MaxElapsedTime  time.Duration    // Give up once a retry would end past it, 0 means unlimited.
+	IsRetryable     func(error) bool // Defaults to fxerror.IsRetryable.
+	Clock           Clock            // Defaults to SystemClock, stops its timers when it is a StoppableClock.
+	Rand            func() float64   // Source of jitter in [0, 1), defaults to math/rand.
+}
+
+// DefaultRetryPolicy returns a policy making up to 5 attempts with an exponential
+// backoff starting at 100ms.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:     5,
+		InitialInterval: 100 * time.Millisecond,
+		MaxInterval:     10 * time.Second,
+		Multiplier:      2,
+		Jitter:          0.5,
+		MaxElapsedTime:  time.Minute,
+	}
+}
+
+// NewRetryPolicy builds a policy from DefaultRetryPolicy and the given enhancers.
+func NewRetryPolicy(opts ...OptionEnhancer[RetryPolicy]) RetryPolicy {
+	return OptionBuilder(DefaultRetryPolicy, opts...)
+}
+
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	if p.IsRetryable == nil {
+		p.IsRetryable = fxerror.IsRetryable
+	}
+	if p.Clock == nil {
+		p.Clock = SystemClock()
+	}
+	if p.Rand == nil {
+		p.Rand = rand.Float64
+	}
+	if p.Multiplier < 1 {
+		p.Multiplier = 1
+	}
+	return p
+}
+
+// wait returns the jittered wait for the given backoff interval.
+func (p RetryPolicy) wait(interval time.Duration) time.Duration {
+	if p.Jitter <= 0 {
+		return interval
+	}
+	delta := p.Jitter * float64(interval)
+	wait := float64(interval) - delta + 2*delta*p.Rand()
+	if wait >= float64(maxDuration) {
+		return maxDuration
+	}
+	return time.Duration(wait)
+}
+
+// maxDuration is the longest time.Duration, backoff intervals saturate at it.
+const maxDuration = time.Duration(math.MaxInt64)
+
+// next returns the backoff interval following interval.
+func (p RetryPolicy) next(interval time.Duration) time.Duration {
+	next := maxDuration
+	if grown := float64(interval) * p.Multiplier; grown < float64(maxDuration) {
+		next = time.Duration(grown)
+	}
+	if p.MaxInterval > 0 && next > p.MaxInterval {
+		return p.MaxInterval
+	}
+	return next
+}
+
+// Retry calls fn until it succeeds, fails with an error the policy does not
+// consider retryable, or the policy gives up, and returns the last Result.
+// Like FlatMapCtx, a cancelled context stops the retries and produces a failure
+// holding ctx.Err().
+func Retry[T any](ctx context.Context, policy RetryPolicy, fn func(context.Context) Result[T]) Result[T] {
+	policy = policy.withDefaults()
+	start := policy.Clock.Now()
+	interval := policy.InitialInterval
+	if policy.MaxInterval > 0 && interval > policy.MaxInterval {
+		interval = policy.MaxInterval
+	}
+
+	for attempt := 1; ; attempt++ {
+		if ctx.Err() != nil {
+			return NewFailure[T](ctx.Err())
+		}
+		res := fn(ctx)
+		if res.IsSuccess() || !policy.IsRetryable(res.AsError()) {
+			return res
+		}
+		if policy.MaxAttempts > 0 && attempt >= policy.MaxAttempts {
+			return res
+		}
+
+		wait := policy.wait(interval)
+		if policy.MaxElapsedTime > 0 && policy.Clock.Now().Add(wait).Sub(start) > policy.MaxElapsedTime {
+			return res
+		}
+		if err := sleepCtx(ctx, policy.Clock, wait); err != nil {
+			return NewFailure[T](err)
+		}
+		interval = policy.next(interval)
+	}
+}
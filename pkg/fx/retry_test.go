@@ -0,0 +1,201 @@
+package fx
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	fxerror "github.com/fredsh/go-fxtend/pkg/fx-error"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetry(t *testing.T) {
+	errTransient := fxerror.MarkRetryable(errors.New("transient"))
+	errFatal := errors.New("fatal")
+
+	basePolicy := func(opts ...OptionEnhancer[RetryPolicy]) RetryPolicy {
+		opts = append([]OptionEnhancer[RetryPolicy]{func(p RetryPolicy) RetryPolicy {
+			p.Jitter = 0
+			return p
+		}}, opts...)
+		return NewRetryPolicy(opts...)
+	}
+
+	cases := []struct {
+		name      string
+		policy    RetryPolicy
+		outcomes  []error
+		wantCalls int
+		wantErr   error
+		wantWaits []time.Duration
+	}{
+		{
+			name:      "success on first attempt does not retry",
+			policy:    basePolicy(),
+			outcomes:  []error{nil},
+			wantCalls: 1,
+		},
+		{
+			name:      "retryable failures are retried with exponential backoff",
+			policy:    basePolicy(),
+			outcomes:  []error{errTransient, errTransient, errTransient, nil},
+			wantCalls: 4,
+			wantWaits: []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond},
+		},
+		{
+			name:      "unavailable errors are retryable by default",
+			policy:    basePolicy(),
+			outcomes:  []error{fxerror.ErrUnavailable, nil},
+			wantCalls: 2,
+			wantWaits: []time.Duration{100 * time.Millisecond},
+		},
+		{
+			name:      "non retryable failure is returned right away",
+			policy:    basePolicy(),
+			outcomes:  []error{errFatal, nil},
+			wantCalls: 1,
+			wantErr:   errFatal,
+		},
+		{
+			name: "custom predicate decides what is retryable",
+			policy: basePolicy(func(p RetryPolicy) RetryPolicy {
+				p.IsRetryable = func(err error) bool { return errors.Is(err, errFatal) }
+				return p
+			}),
+			outcomes:  []error{errFatal, nil},
+			wantCalls: 2,
+			wantWaits: []time.Duration{100 * time.Millisecond},
+		},
+		{
+			name: "max attempts returns the last failure",
+			policy: basePolicy(func(p RetryPolicy) RetryPolicy {
+				p.MaxAttempts = 3
+				return p
+			}),
+			outcomes:  []error{errTransient, errTransient, errTransient, nil},
+			wantCalls: 3,
+			wantErr:   errTransient,
+			wantWaits: []time.Duration{100 * time.Millisecond, 200 * time.Millisecond},
+		},
+		{
+			name: "backoff is capped by max interval",
+			policy: basePolicy(func(p RetryPolicy) RetryPolicy {
+				p.MaxInterval = 250 * time.Millisecond
+				return p
+			}),
+			outcomes:  []error{errTransient, errTransient, errTransient, nil},
+			wantCalls: 4,
+			wantWaits: []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 250 * time.Millisecond},
+		},
+		{
+			name: "max elapsed time stops retrying",
+			policy: basePolicy(func(p RetryPolicy) RetryPolicy {
+				p.MaxAttempts = 0
+				p.MaxElapsedTime = time.Second
+				return p
+			}),
+			outcomes:  []error{errTransient, errTransient, errTransient, errTransient, errTransient, nil},
+			wantCalls: 4,
+			wantErr:   errTransient,
+			wantWaits: []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond},
+		},
+		{
+			name: "jitter randomizes the wait",
+			policy: basePolicy(func(p RetryPolicy) RetryPolicy {
+				p.Jitter = 0.5
+				p.Rand = func() float64 { return 0 }
+				return p
+			}),
+			outcomes:  []error{errTransient, errTransient, nil},
+			wantCalls: 3,
+			wantWaits: []time.Duration{50 * time.Millisecond, 100 * time.Millisecond},
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := newFakeClock()
+			tt.policy.Clock = clock
+			calls := 0
+			res := Retry(context.Background(), tt.policy, func(ctx context.Context) Result[int] {
+				err := tt.outcomes[calls]
+				calls++
+				if err != nil {
+					return NewFailure[int](err)
+				}
+				return NewSuccess(calls)
+			})
+
+			require.Equal(t, tt.wantCalls, calls)
+			require.Equal(t, tt.wantWaits, clock.Waits())
+			if tt.wantErr != nil {
+				require.ErrorIs(t, res.AsError(), tt.wantErr)
+			} else {
+				require.NoError(t, res.AsError())
+				require.Equal(t, calls, res.Unwrap())
+			}
+		})
+	}
+}
+
+func TestRetryContext(t *testing.T) {
+	policy := NewRetryPolicy(func(p RetryPolicy) RetryPolicy {
+		p.Clock = newFakeClock()
+		return p
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	calls := 0
+	res := Retry(ctx, policy, func(ctx context.Context) Result[int] {
+		calls++
+		return NewSuccess(1)
+	})
+	require.Equal(t, 0, calls)
+	require.ErrorIs(t, res.AsError(), context.Canceled)
+
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	res = Retry(ctx, policy, func(ctx context.Context) Result[int] {
+		calls++
+		cancel()
+		return NewFailure[int](fxerror.ErrTimeout)
+	})
+	require.Equal(t, 1, calls)
+	require.ErrorIs(t, res.AsError(), context.Canceled)
+}
+
+func TestRetryBackoffSaturates(t *testing.T) {
+	policy := NewRetryPolicy(func(p RetryPolicy) RetryPolicy {
+		p.MaxInterval = 0
+		p.Multiplier = 10
+		p.Rand = func() float64 { return 0.999 }
+		return p
+	}).withDefaults()
+
+	interval := time.Second
+	for i := 0; i < 40; i++ {
+		next := policy.next(interval)
+		require.GreaterOrEqual(t, next, interval, "backoff must never overflow")
+		require.Positive(t, policy.wait(next))
+		interval = next
+	}
+	require.Equal(t, maxDuration, interval)
+}
+
+func TestRetryStopsTimerOnCancel(t *testing.T) {
+	policy := NewRetryPolicy(func(p RetryPolicy) RetryPolicy {
+		p.InitialInterval = time.Hour
+		p.MaxInterval = time.Hour
+		p.MaxElapsedTime = 0
+		return p
+	})
+	var _ StoppableClock = SystemClock().(StoppableClock)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	res := Retry(ctx, policy, func(ctx context.Context) Result[int] {
+		return NewFailure[int](fxerror.ErrTimeout)
+	})
+	require.ErrorIs(t, res.AsError(), context.DeadlineExceeded)
+}
@@ -0,0 +1,224 @@
+package fx
+
+import (
+	"fmt"
+	"strings"
+)
+
+// EditKind is the kind of an Edit.
+type EditKind int
+
+const (
+	EditKeep EditKind = iota
+	EditDelete
+	EditInsert
+)
+
+// String returns the name of the kind.
+func (k EditKind) String() string {
+	switch k {
+	case EditKeep:
+		return "keep"
+	case EditDelete:
+		return "delete"
+	case EditInsert:
+		return "insert"
+	}
+	return "unknown"
+}
+
+// Edit is one operation of an edit script turning a slice a into a slice b.
+// OldIndex and NewIndex are the positions in a and b where it applies: a Keep
+// matches a[OldIndex] with b[NewIndex], a Delete removes a[OldIndex] and an
+// Insert adds b[NewIndex].
+type Edit struct {
+	Kind     EditKind
+	OldIndex int
+	NewIndex int
+}
+
+// SliceDiff returns the shortest edit script turning a into b, see SliceDiffFunc.
+func SliceDiff[T comparable](a, b []T) []Edit {
+	return SliceDiffFunc(a, b, func(x, y T) bool { return x == y })
+}
+
+// SliceDiffFunc returns the shortest edit script turning a into b, elements being
+// compared with eq. It implements Myers' O(ND) algorithm with its linear space
+// refinement, the script lists every element of a and b once, in order, deletions
+// coming before insertions within a change.
+func SliceDiffFunc[T any](a, b []T, eq func(T, T) bool) []Edit {
+	d := &differ{
+		eq:    func(i, j int) bool { return eq(a[i], b[j]) },
+		edits: make([]Edit, 0, len(a)+len(b)),
+	}
+	d.diff(0, len(a), 0, len(b))
+	return d.edits
+}
+
+type differ struct {
+	eq     func(i, j int) bool
+	edits  []Edit
+	vf, vb []int
+}
+
+// diff appends the edit script of a[a0:a1] into b[b0:b1].
+func (d *differ) diff(a0, a1, b0, b1 int) {
+	for a0 < a1 && b0 < b1 && d.eq(a0, b0) {
+		d.edits = append(d.edits, Edit{Kind: EditKeep, OldIndex: a0, NewIndex: b0})
+		a0, b0 = a0+1, b0+1
+	}
+	suffix := 0
+	for a0 < a1-suffix && b0 < b1-suffix && d.eq(a1-suffix-1, b1-suffix-1) {
+		suffix++
+	}
+	a1, b1 = a1-suffix, b1-suffix
+
+	switch {
+	case a0 == a1:
+		for j := b0; j < b1; j++ {
+			d.edits = append(d.edits, Edit{Kind: EditInsert, OldIndex: a0, NewIndex: j})
+		}
+	case b0 == b1:
+		for i := a0; i < a1; i++ {
+			d.edits = append(d.edits, Edit{Kind: EditDelete, OldIndex: i, NewIndex: b0})
+		}
+	default:
+		x0, y0, x1, y1 := d.middleSnake(a0, a1, b0, b1)
+		d.diff(a0, x0, b0, y0)
+		for i, j := x0, y0; i < x1; i, j = i+1, j+1 {
+			d.edits = append(d.edits, Edit{Kind: EditKeep, OldIndex: i, NewIndex: j})
+		}
+		d.diff(x1, a1, y1, b1)
+	}
+
+	for k := 0; k < suffix; k++ {
+		d.edits = append(d.edits, Edit{Kind: EditKeep, OldIndex: a1 + k, NewIndex: b1 + k})
+	}
+}
+
+// middleSnake finds the snake from (x0, y0) to (x1, y1) in the middle of a
+// shortest edit path of a[a0:a1] into b[b0:b1], running the greedy search from
+// both ends until they overlap.
+func (d *differ) middleSnake(a0, a1, b0, b1 int) (x0, y0, x1, y1 int) {
+	n, m := a1-a0, b1-b0
+	delta := n - m
+	odd := delta%2 != 0
+	maxD := (n + m + 1) / 2
+	offset := maxD + 1
+	size := 2*maxD + 3
+	if cap(d.vf) < size {
+		d.vf, d.vb = make([]int, size), make([]int, size)
+	}
+	vf, vb := d.vf[:size], d.vb[:size]
+	vf[offset+1], vb[offset+1] = 0, 0
+
+	for step := 0; step <= maxD; step++ {
+		for k := -step; k <= step; k += 2 {
+			var x int
+			if k == -step || (k != step && vf[offset+k-1] < vf[offset+k+1]) {
+				x = vf[offset+k+1]
+			} else {
+				x = vf[offset+k-1] + 1
+			}
+			y := x - k
+			sx, sy := x, y
+			for x < n && y < m && d.eq(a0+x, b0+y) {
+				x, y = x+1, y+1
+			}
+			vf[offset+k] = x
+			if c := delta - k; odd && c >= -(step-1) && c <= step-1 && x+vb[offset+c] >= n {
+				return a0 + sx, b0 + sy, a0 + x, b0 + y
+			}
+		}
+		for k := -step; k <= step; k += 2 {
+			var x int
+			if k == -step || (k != step && vb[offset+k-1] < vb[offset+k+1]) {
+				x = vb[offset+k+1]
+			} else {
+				x = vb[offset+k-1] + 1
+			}
+			y := x - k
+			sx, sy := x, y
+			for x < n && y < m && d.eq(a1-x-1, b1-y-1) {
+				x, y = x+1, y+1
+			}
+			vb[offset+k] = x
+			if c := delta - k; !odd && c >= -step && c <= step && x+vf[offset+c] >= n {
+				return a1 - x, b1 - y, a1 - sx, b1 - sy
+			}
+		}
+	}
+	// unreachable, the searches always overlap once step reaches maxD
+	return a0, b0, a1, b1
+}
+
+// UnifiedDiff renders the differences between the lines a and b in the unified
+// diff format, with context lines of context around each change. It returns an
+// empty string if a and b are equal. Lines must not end with a newline.
+func UnifiedDiff(fromName, toName string, a, b []string, context int) string {
+	edits := SliceDiff(a, b)
+	var changes []int
+	for i, e := range edits {
+		if e.Kind != EditKeep {
+			changes = append(changes, i)
+		}
+	}
+	if len(changes) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", fromName, toName)
+	for i := 0; i < len(changes); {
+		last := i
+		for last+1 < len(changes) && changes[last+1]-changes[last]-1 <= 2*context {
+			last++
+		}
+		start := changes[i] - context
+		if start < 0 {
+			start = 0
+		}
+		end := changes[last] + context + 1
+		if end > len(edits) {
+			end = len(edits)
+		}
+		writeHunk(&sb, edits[start:end], a, b)
+		i = last + 1
+	}
+	return sb.String()
+}
+
+func writeHunk(sb *strings.Builder, hunk []Edit, a, b []string) {
+	oldCount, newCount := 0, 0
+	for _, e := range hunk {
+		if e.Kind != EditInsert {
+			oldCount++
+		}
+		if e.Kind != EditDelete {
+			newCount++
+		}
+	}
+	fmt.Fprintf(sb, "@@ -%s +%s @@\n", hunkRange(hunk[0].OldIndex, oldCount), hunkRange(hunk[0].NewIndex, newCount))
+	for _, e := range hunk {
+		switch e.Kind {
+		case EditKeep:
+			fmt.Fprintf(sb, " %s\n", a[e.OldIndex])
+		case EditDelete:
+			fmt.Fprintf(sb, "-%s\n", a[e.OldIndex])
+		case EditInsert:
+			fmt.Fprintf(sb, "+%s\n", b[e.NewIndex])
+		}
+	}
+}
+
+// hunkRange formats the 1-based range of a hunk, an empty range refers to the
+// line before it.
+func hunkRange(start, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", start)
+	case 1:
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
@@ -0,0 +1,155 @@
+package fx
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// applyEdits checks that edits is a valid script turning a into b and returns
+// its number of insertions and deletions.
+func applyEdits[T comparable](t *testing.T, a, b []T, edits []Edit) int {
+	var got []T
+	i, j, cost := 0, 0, 0
+	for _, e := range edits {
+		require.Equal(t, i, e.OldIndex)
+		require.Equal(t, j, e.NewIndex)
+		switch e.Kind {
+		case EditKeep:
+			require.Equal(t, a[i], b[j])
+			got = append(got, a[i])
+			i, j = i+1, j+1
+		case EditDelete:
+			i++
+			cost++
+		case EditInsert:
+			got = append(got, b[j])
+			j++
+			cost++
+		}
+	}
+	require.Equal(t, len(a), i)
+	require.Equal(t, len(b), j)
+	require.Equal(t, len(b), len(got))
+	return cost
+}
+
+// lcsLen is the textbook quadratic longest common subsequence length.
+func lcsLen[T comparable](a, b []T) int {
+	prev := make([]int, len(b)+1)
+	for i := range a {
+		cur := make([]int, len(b)+1)
+		for j := range b {
+			if a[i] == b[j] {
+				cur[j+1] = prev[j] + 1
+			} else if prev[j+1] > cur[j] {
+				cur[j+1] = prev[j+1]
+			} else {
+				cur[j+1] = cur[j]
+			}
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
+func TestSliceDiff(t *testing.T) {
+	cases := []struct {
+		name string
+		a    string
+		b    string
+		want []Edit
+	}{
+		{name: "empty slices produce an empty script", want: []Edit{}},
+		{
+			name: "insertions into an empty slice",
+			b:    "ab",
+			want: []Edit{{EditInsert, 0, 0}, {EditInsert, 0, 1}},
+		},
+		{
+			name: "deletions from a slice",
+			a:    "ab",
+			want: []Edit{{EditDelete, 0, 0}, {EditDelete, 1, 0}},
+		},
+		{
+			name: "changes between common elements",
+			a:    "abcd",
+			b:    "axcyd",
+			want: []Edit{
+				{EditKeep, 0, 0}, {EditDelete, 1, 1}, {EditInsert, 2, 1}, {EditKeep, 2, 2}, {EditInsert, 3, 3}, {EditKeep, 3, 4},
+			},
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := []byte(tt.a), []byte(tt.b)
+			got := SliceDiff(a, b)
+			require.Equal(t, tt.want, got)
+			applyEdits(t, a, b, got)
+		})
+	}
+
+	// Myers' example from the paper has an edit distance of 5.
+	require.Equal(t, 5, applyEdits(t, []byte("abcabba"), []byte("cbabac"), SliceDiff([]byte("abcabba"), []byte("cbabac"))))
+
+	rnd := rand.New(rand.NewSource(1))
+	for n := 0; n < 300; n++ {
+		a := make([]int, rnd.Intn(40))
+		b := make([]int, rnd.Intn(40))
+		for i := range a {
+			a[i] = rnd.Intn(4)
+		}
+		for i := range b {
+			b[i] = rnd.Intn(4)
+		}
+		cost := applyEdits(t, a, b, SliceDiff(a, b))
+		require.Equal(t, len(a)+len(b)-2*lcsLen(a, b), cost, "script for %v -> %v is not minimal", a, b)
+	}
+}
+
+func TestSliceDiffFunc(t *testing.T) {
+	a := []string{"Alpha", "beta"}
+	b := []string{"alpha", "BETA", "gamma"}
+	got := SliceDiffFunc(a, b, strings.EqualFold)
+	require.Equal(t, []Edit{{EditKeep, 0, 0}, {EditKeep, 1, 1}, {EditInsert, 2, 2}}, got)
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	a := strings.Split("a b c d e f g h i j k", " ")
+	b := strings.Split("a b x d e f g h i k l", " ")
+
+	require.Empty(t, UnifiedDiff("old", "new", a, a, 3))
+	require.Equal(t, `--- old
++++ new
+@@ -1,11 +1,11 @@
+ a
+ b
+-c
++x
+ d
+ e
+ f
+ g
+ h
+ i
+-j
+ k
++l
+`, UnifiedDiff("old", "new", a, b, 3))
+	require.Equal(t, `--- old
++++ new
+@@ -2,3 +2,3 @@
+ b
+-c
++x
+ d
+@@ -9,3 +9,3 @@
+ i
+-j
+ k
++l
+`, UnifiedDiff("old", "new", a, b, 1))
+	require.Equal(t, "--- old\n+++ new\n@@ -0,0 +1 @@\n+a\n", UnifiedDiff("old", "new", nil, []string{"a"}, 3))
+}
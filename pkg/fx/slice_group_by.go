@@ -16,3 +16,85 @@ func SliceGroupBy[T any, K comparable](input []T, keySelector KeySelector[T, K])
 	}
 	return res
 }
+
+// Number is satisfied by the integer and float types.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// MinMax holds the smallest and greatest items of a group.
+type MinMax[T any] struct {
+	Min T
+	Max T
+}
+
+// SliceGroupByReduce folds the items of input sharing the same key, starting
+// from init for every key, without building the groups. init is copied for
+// every key so it should not hold a map or a slice that reducer modifies.
+func SliceGroupByReduce[T any, K comparable, A any](input []T, keySelector KeySelector[T, K], init A, reducer func(A, T) A) map[K]A {
+	res := map[K]A{}
+	for _, v := range input {
+		key := keySelector(v)
+		acc, found := res[key]
+		if !found {
+			acc = init
+		}
+		res[key] = reducer(acc, v)
+	}
+	return res
+}
+
+// GroupByCount counts the items of input sharing the same key, like
+// SliceCountBy.
+func GroupByCount[T any, K comparable](input []T, keySelector KeySelector[T, K]) map[K]int {
+	return SliceCountBy(input, keySelector)
+}
+
+// GroupBySum sums the values returned by valueFn for the items of input sharing
+// the same key.
+func GroupBySum[T any, K comparable, N Number](input []T, keySelector KeySelector[T, K], valueFn func(T) N) map[K]N {
+	return SliceGroupByReduce(input, keySelector, 0, func(sum N, v T) N {
+		return sum + valueFn(v)
+	})
+}
+
+// GroupByMinMax returns the first smallest and first greatest items according to
+// cmp among the items of input sharing the same key.
+func GroupByMinMax[T any, K comparable](input []T, keySelector KeySelector[T, K], cmp Comparator[T]) map[K]MinMax[T] {
+	res := map[K]MinMax[T]{}
+	for _, v := range input {
+		key := keySelector(v)
+		mm, found := res[key]
+		if !found {
+			res[key] = MinMax[T]{Min: v, Max: v}
+			continue
+		}
+		if cmp(v, mm.Min) < 0 {
+			mm.Min = v
+		}
+		if cmp(v, mm.Max) > 0 {
+			mm.Max = v
+		}
+		res[key] = mm
+	}
+	return res
+}
+
+// GroupBy2 groups input by two levels of keys, the items sharing both keys are
+// grouped together as slice under the nested maps.
+func GroupBy2[T any, K1 comparable, K2 comparable](input []T, keySelector1 KeySelector[T, K1], keySelector2 KeySelector[T, K2]) map[K1]map[K2][]T {
+	res := map[K1]map[K2][]T{}
+	for _, v := range input {
+		key1 := keySelector1(v)
+		inner, found := res[key1]
+		if !found {
+			inner = map[K2][]T{}
+			res[key1] = inner
+		}
+		key2 := keySelector2(v)
+		inner[key2] = append(inner[key2], v)
+	}
+	return res
+}
@@ -0,0 +1,63 @@
+package fx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type sale struct {
+	Region  string
+	Product string
+	Amount  float64
+}
+
+var sales = []sale{
+	{Region: "eu", Product: "tea", Amount: 10},
+	{Region: "us", Product: "tea", Amount: 4},
+	{Region: "eu", Product: "coffee", Amount: 25},
+	{Region: "eu", Product: "tea", Amount: 5},
+	{Region: "us", Product: "cocoa", Amount: 7},
+}
+
+func byRegion(s sale) string  { return s.Region }
+func byProduct(s sale) string { return s.Product }
+func byAmount(s sale) float64 { return s.Amount }
+
+func TestSliceGroupBy(t *testing.T) {
+	require.Equal(t, map[string][]sale{
+		"eu": {sales[0], sales[2], sales[3]},
+		"us": {sales[1], sales[4]},
+	}, SliceGroupBy(sales, byRegion))
+	require.Equal(t, map[string][]sale{}, SliceGroupBy(nil, byRegion))
+}
+
+func TestSliceGroupByReduce(t *testing.T) {
+	products := SliceGroupByReduce(sales, byRegion, "", func(acc string, s sale) string {
+		return acc + s.Product[:1]
+	})
+	require.Equal(t, map[string]string{"eu": "tct", "us": "tc"}, products)
+	require.Equal(t, map[string]int{}, SliceGroupByReduce(nil, byRegion, 0, func(acc int, s sale) int { return acc + 1 }))
+
+	require.Equal(t, map[string]int{"eu": 3, "us": 2}, GroupByCount(sales, byRegion))
+	require.Equal(t, map[string]float64{"eu": 40, "us": 11}, GroupBySum(sales, byRegion, byAmount))
+	require.Equal(t, map[string]int{"tea": 3, "coffee": 1, "cocoa": 1}, GroupBySum(sales, byProduct, func(sale) int { return 1 }))
+}
+
+func TestGroupByMinMax(t *testing.T) {
+	require.Equal(t, map[string]MinMax[sale]{
+		"eu": {Min: sales[3], Max: sales[2]},
+		"us": {Min: sales[1], Max: sales[4]},
+	}, GroupByMinMax(sales, byRegion, CompareBy(byAmount)))
+
+	ties := []sale{{Region: "eu", Product: "a"}, {Region: "eu", Product: "b"}}
+	require.Equal(t, MinMax[sale]{Min: ties[0], Max: ties[0]}, GroupByMinMax(ties, byRegion, CompareBy(byAmount))["eu"], "the first of equal items wins")
+}
+
+func TestGroupBy2(t *testing.T) {
+	require.Equal(t, map[string]map[string][]sale{
+		"eu": {"tea": {sales[0], sales[3]}, "coffee": {sales[2]}},
+		"us": {"tea": {sales[1]}, "cocoa": {sales[4]}},
+	}, GroupBy2(sales, byRegion, byProduct))
+	require.Equal(t, map[string]map[string][]sale{}, GroupBy2(nil, byRegion, byProduct))
+}
@@ -0,0 +1,204 @@
+package fx
+
+import (
+	"container/heap"
+	"sort"
+)
+
+// Ordered is satisfied by the types supporting the < operator.
+type Ordered interface {
+	Number | ~string
+}
+
+// Comparator returns a negative number when a sorts before b, a positive number
+// when a sorts after b and 0 when their order does not matter.
+type Comparator[T any] func(a, b T) int
+
+// Compare is the natural Comparator of ordered values, NaN sorting before any
+// other float.
+func Compare[T Ordered](a, b T) int {
+	aNaN, bNaN := a != a, b != b
+	switch {
+	case aNaN && bNaN:
+		return 0
+	case aNaN || a < b:
+		return -1
+	case bNaN || a > b:
+		return 1
+	}
+	return 0
+}
+
+// CompareBy returns a Comparator ordering values by the key returned by keyFn.
+func CompareBy[T any, K Ordered](keyFn func(T) K) Comparator[T] {
+	return func(a, b T) int {
+		return Compare(keyFn(a), keyFn(b))
+	}
+}
+
+// NilsFirst returns a Comparator ordering values by the optional key returned by
+// keyFn, None keys sorting first.
+func NilsFirst[T any, K Ordered](keyFn func(T) Maybe[K]) Comparator[T] {
+	return compareMaybeBy(keyFn, -1)
+}
+
+// NilsLast returns a Comparator ordering values by the optional key returned by
+// keyFn, None keys sorting last.
+func NilsLast[T any, K Ordered](keyFn func(T) Maybe[K]) Comparator[T] {
+	return compareMaybeBy(keyFn, 1)
+}
+
+func compareMaybeBy[T any, K Ordered](keyFn func(T) Maybe[K], none int) Comparator[T] {
+	return func(a, b T) int {
+		ka, kb := keyFn(a), keyFn(b)
+		switch {
+		case ka.IsNone() && kb.IsNone():
+			return 0
+		case ka.IsNone():
+			return none
+		case kb.IsNone():
+			return -none
+		}
+		return Compare(ka.Unwrap(), kb.Unwrap())
+	}
+}
+
+// ThenBy returns a Comparator breaking the ties of c with next.
+func (c Comparator[T]) ThenBy(next Comparator[T]) Comparator[T] {
+	return func(a, b T) int {
+		if res := c(a, b); res != 0 {
+			return res
+		}
+		return next(a, b)
+	}
+}
+
+// Reversed returns a Comparator ordering values the other way around.
+func (c Comparator[T]) Reversed() Comparator[T] {
+	return func(a, b T) int {
+		return c(b, a)
+	}
+}
+
+// SliceSortBy returns a sorted copy of input, ordered by the key returned by
+// keyFn. Keys are computed once per element and the sort is not stable.
+func SliceSortBy[T any, K Ordered](input []T, keyFn func(T) K) []T {
+	s := keyedSlice[T, K]{values: make([]T, len(input)), keys: make([]K, len(input))}
+	copy(s.values, input)
+	for i, v := range input {
+		s.keys[i] = keyFn(v)
+	}
+	sort.Sort(s)
+	return s.values
+}
+
+// SliceSortFunc returns a copy of input sorted with cmp, the sort is not stable.
+func SliceSortFunc[T any](input []T, cmp Comparator[T]) []T {
+	res := make([]T, len(input))
+	copy(res, input)
+	sort.Slice(res, func(i, j int) bool { return cmp(res[i], res[j]) < 0 })
+	return res
+}
+
+// SliceSortStable returns a copy of input sorted with cmp, keeping the original
+// order of equal elements.
+func SliceSortStable[T any](input []T, cmp Comparator[T]) []T {
+	res := make([]T, len(input))
+	copy(res, input)
+	sort.SliceStable(res, func(i, j int) bool { return cmp(res[i], res[j]) < 0 })
+	return res
+}
+
+// SliceIsSorted returns true if input is sorted according to cmp.
+func SliceIsSorted[T any](input []T, cmp Comparator[T]) bool {
+	for i := 1; i < len(input); i++ {
+		if cmp(input[i-1], input[i]) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// SliceTopK returns the k greatest elements of input according to cmp, greatest
+// first. It keeps a heap of k elements and runs in O(n log k).
+func SliceTopK[T any](input []T, k int, cmp Comparator[T]) []T {
+	if k <= 0 {
+		return []T{}
+	}
+	if k > len(input) {
+		k = len(input)
+	}
+	h := &topKHeap[T]{cmp: cmp, values: make([]T, 0, k)}
+	for _, v := range input {
+		if len(h.values) < k {
+			heap.Push(h, v)
+		} else if cmp(v, h.values[0]) > 0 {
+			h.values[0] = v
+			heap.Fix(h, 0)
+		}
+	}
+	res := make([]T, len(h.values))
+	for i := len(res) - 1; i >= 0; i-- {
+		res[i] = heap.Pop(h).(T)
+	}
+	return res
+}
+
+// SliceMinBy returns the first smallest element of input according to cmp, or
+// None if input is empty.
+func SliceMinBy[T any](input []T, cmp Comparator[T]) Maybe[T] {
+	if len(input) == 0 {
+		return NewNone[T]()
+	}
+	res := input[0]
+	for _, v := range input[1:] {
+		if cmp(v, res) < 0 {
+			res = v
+		}
+	}
+	return NewSome(res)
+}
+
+// SliceMaxBy returns the first greatest element of input according to cmp, or
+// None if input is empty.
+func SliceMaxBy[T any](input []T, cmp Comparator[T]) Maybe[T] {
+	if len(input) == 0 {
+		return NewNone[T]()
+	}
+	res := input[0]
+	for _, v := range input[1:] {
+		if cmp(v, res) > 0 {
+			res = v
+		}
+	}
+	return NewSome(res)
+}
+
+// keyedSlice sorts values along with their precomputed keys.
+type keyedSlice[T any, K Ordered] struct {
+	values []T
+	keys   []K
+}
+
+func (s keyedSlice[T, K]) Len() int           { return len(s.values) }
+func (s keyedSlice[T, K]) Less(i, j int) bool { return Compare(s.keys[i], s.keys[j]) < 0 }
+func (s keyedSlice[T, K]) Swap(i, j int) {
+	s.values[i], s.values[j] = s.values[j], s.values[i]
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+}
+
+// topKHeap is a min-heap according to cmp.
+type topKHeap[T any] struct {
+	cmp    Comparator[T]
+	values []T
+}
+
+func (h *topKHeap[T]) Len() int           { return len(h.values) }
+func (h *topKHeap[T]) Less(i, j int) bool { return h.cmp(h.values[i], h.values[j]) < 0 }
+func (h *topKHeap[T]) Swap(i, j int)      { h.values[i], h.values[j] = h.values[j], h.values[i] }
+func (h *topKHeap[T]) Push(x any)         { h.values = append(h.values, x.(T)) }
+func (h *topKHeap[T]) Pop() any {
+	last := h.values[len(h.values)-1]
+	h.values = h.values[:len(h.values)-1]
+	return last
+}
@@ -0,0 +1,108 @@
+package fx
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type employee struct {
+	Name    string
+	Dept    string
+	Age     int
+	Manager Maybe[string]
+}
+
+var employees = []employee{
+	{Name: "carol", Dept: "ops", Age: 41, Manager: NewSome("alice")},
+	{Name: "alice", Dept: "eng", Age: 52},
+	{Name: "dave", Dept: "eng", Age: 30, Manager: NewSome("alice")},
+	{Name: "bob", Dept: "ops", Age: 30, Manager: NewSome("carol")},
+	{Name: "erin", Dept: "eng", Age: 30},
+}
+
+func names(input []employee) []string {
+	res := make([]string, len(input))
+	for i, e := range input {
+		res[i] = e.Name
+	}
+	return res
+}
+
+func byName(e employee) string { return e.Name }
+func byAge(e employee) int     { return e.Age }
+func byDept(e employee) string { return e.Dept }
+
+func TestComparator(t *testing.T) {
+	byDeptThenAgeDesc := CompareBy(byDept).ThenBy(CompareBy(byAge).Reversed()).ThenBy(CompareBy(byName))
+	byManager := func(e employee) Maybe[string] { return e.Manager }
+
+	cases := []struct {
+		name string
+		cmp  Comparator[employee]
+		want []string
+	}{
+		{name: "single key", cmp: CompareBy(byName), want: []string{"alice", "bob", "carol", "dave", "erin"}},
+		{name: "reversed key", cmp: CompareBy(byName).Reversed(), want: []string{"erin", "dave", "carol", "bob", "alice"}},
+		{name: "multiple keys", cmp: byDeptThenAgeDesc, want: []string{"alice", "dave", "erin", "carol", "bob"}},
+		{name: "nils first", cmp: NilsFirst(byManager).ThenBy(CompareBy(byName)), want: []string{"alice", "erin", "carol", "dave", "bob"}},
+		{name: "nils last", cmp: NilsLast(byManager).ThenBy(CompareBy(byName)), want: []string{"carol", "dave", "bob", "alice", "erin"}},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			sorted := SliceSortFunc(employees, tt.cmp)
+			require.Equal(t, tt.want, names(sorted))
+			require.True(t, SliceIsSorted(sorted, tt.cmp))
+			require.False(t, SliceIsSorted(SliceSortFunc(employees, tt.cmp.Reversed()), tt.cmp))
+		})
+	}
+
+	floats := SliceSortFunc([]float64{2, math.NaN(), -1}, Compare[float64])
+	require.True(t, math.IsNaN(floats[0]), "NaN sorts first")
+	require.Equal(t, []float64{-1, 2}, floats[1:])
+}
+
+func TestSliceSort(t *testing.T) {
+	input := append([]employee(nil), employees...)
+
+	require.Equal(t, []string{"dave", "bob", "erin", "carol", "alice"}, names(SliceSortStable(input, CompareBy(byAge))), "ties keep their order")
+	require.Equal(t, []string{"alice", "bob", "carol", "dave", "erin"}, names(SliceSortBy(input, byName)))
+	require.Equal(t, employees, input, "input is not modified")
+	require.Empty(t, SliceSortBy([]employee{}, byName))
+	require.True(t, SliceIsSorted([]int{}, Compare[int]))
+}
+
+func TestSliceTopK(t *testing.T) {
+	cases := []struct {
+		name  string
+		input []int
+		k     int
+		want  []int
+	}{
+		{name: "k greatest elements greatest first", input: []int{5, 1, 9, 3, 7, 9}, k: 3, want: []int{9, 9, 7}},
+		{name: "k above length returns everything sorted", input: []int{2, 3, 1}, k: 5, want: []int{3, 2, 1}},
+		{name: "k of 0 returns nothing", input: []int{1}, k: 0, want: []int{}},
+		{name: "empty input returns nothing", input: nil, k: 2, want: []int{}},
+		{name: "huge k does not allocate k elements", input: []int{2, 3, 1}, k: math.MaxInt, want: []int{3, 2, 1}},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, SliceTopK(tt.input, tt.k, Compare[int]))
+		})
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	input := rnd.Perm(1000)
+	require.Equal(t, []int{0, 1, 2, 3}, SliceTopK(input, 4, Comparator[int](Compare[int]).Reversed()))
+	require.Equal(t, SliceSortFunc(input, Comparator[int](Compare[int]).Reversed())[:10], SliceTopK(input, 10, Compare[int]))
+}
+
+func TestSliceMinMaxBy(t *testing.T) {
+	require.Equal(t, "dave", SliceMinBy(employees, CompareBy(byAge)).Unwrap().Name)
+	require.Equal(t, "alice", SliceMaxBy(employees, CompareBy(byAge)).Unwrap().Name)
+	require.Equal(t, "dave", SliceMaxBy(employees, CompareBy(byAge).Reversed()).Unwrap().Name, "the first of equal elements wins")
+	require.True(t, SliceMinBy([]employee{}, CompareBy(byAge)).IsNone())
+	require.True(t, SliceMaxBy[int](nil, Compare[int]).IsNone())
+}
@@ -1,7 +1,7 @@
 package fx
 
 import (
-	"fmt"
+	fxerror "github.com/fredsh/go-fxtend/pkg/fx-error"
 )
 
 // ToMapWithOverride turns a slice of element of type V and turn it into a map
@@ -24,7 +24,7 @@ func ToMap[K comparable, V any](input []V, keySelector func(item V) K) (map[K]V,
 	for _, v := range input {
 		itemKey := keySelector(v)
 		if _, ok := result[itemKey]; ok {
-			return nil, fmt.Errorf("duplicate key found: %v", itemKey)
+			return nil, fxerror.NewDuplicateKeyError(itemKey)
 		}
 		result[itemKey] = v
 	}
@@ -39,7 +39,7 @@ func ToMapX[K comparable, V any](input []V, keySelector func(item V) K) Result[m
 	for _, v := range input {
 		itemKey := keySelector(v)
 		if _, ok := result[itemKey]; ok {
-			return NewFailure[map[K]V](fmt.Errorf("duplicate key found: %v", itemKey))
+			return NewFailure[map[K]V](fxerror.NewDuplicateKeyError(itemKey))
 		}
 		result[itemKey] = v
 	}
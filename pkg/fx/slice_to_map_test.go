@@ -3,6 +3,7 @@ package fx
 import (
 	"testing"
 
+	fxerror "github.com/fredsh/go-fxtend/pkg/fx-error"
 	"github.com/stretchr/testify/require"
 )
 
@@ -84,6 +85,9 @@ func TestToMap(t *testing.T) {
 				require.Error(t, errPrepared)
 				require.Error(t, resX.AsError())
 				require.Error(t, resPreparedX.AsError())
+				require.True(t, fxerror.IsDuplicateKey(err))
+				require.True(t, fxerror.IsConflict(resX.AsError()))
+				require.Equal(t, fxerror.CodeDuplicateKey, fxerror.CodeOf(resPreparedX.AsError()))
 			} else {
 				require.NoError(t, err)
 				require.NoError(t, errPrepared)
@@ -0,0 +1,104 @@
+package fx
+
+import "context"
+
+// SliceUniq returns the distinct items of input, in the order they are first
+// seen.
+func SliceUniq[T comparable](input []T) []T {
+	return SliceUniqBy(input, func(v T) T { return v })
+}
+
+// SliceUniqBy returns the first item of input for every key returned by the key
+// selector, in the order they are first seen.
+func SliceUniqBy[T any, K comparable](input []T, keySelector KeySelector[T, K]) []T {
+	res := []T{}
+	seen := UniqFilter(keySelector)
+	for _, v := range input {
+		if seen(v) {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+// SliceDuplicates returns the groups of items of input sharing the same key,
+// ignoring the keys held by a single item. Groups are ordered by the first time
+// their key is seen and keep the order of input.
+func SliceDuplicates[T any, K comparable](input []T, keySelector KeySelector[T, K]) [][]T {
+	indexes := map[K]int{}
+	groups := [][]T{}
+	for _, v := range input {
+		key := keySelector(v)
+		if i, found := indexes[key]; found {
+			groups[i] = append(groups[i], v)
+		} else {
+			indexes[key] = len(groups)
+			groups = append(groups, []T{v})
+		}
+	}
+
+	res := [][]T{}
+	for _, group := range groups {
+		if len(group) > 1 {
+			res = append(res, group)
+		}
+	}
+	return res
+}
+
+// SliceCountBy counts the items of input for every key returned by the key
+// selector. Like any map, the result has no order: iterate over the sorted
+// MapGetKeys of it for a deterministic one.
+func SliceCountBy[T any, K comparable](input []T, keySelector KeySelector[T, K]) map[K]int {
+	res := map[K]int{}
+	for _, v := range input {
+		res[keySelector(v)]++
+	}
+	return res
+}
+
+// UniqFilter returns a predicate reporting true the first time it is given an
+// item with a given key and false afterwards, to de-duplicate items produced one
+// at a time. It keeps every key seen and is not safe for concurrent use.
+func UniqFilter[T any, K comparable](keySelector KeySelector[T, K]) func(T) bool {
+	seen := map[K]struct{}{}
+	return func(v T) bool {
+		key := keySelector(v)
+		if _, found := seen[key]; found {
+			return false
+		}
+		seen[key] = struct{}{}
+		return true
+	}
+}
+
+// ChanUniqBy forwards the items received from input whose key was not seen yet,
+// see UniqFilter. The returned channel is closed once input is closed or ctx is
+// done, so that a consumer stopping early does not leak the forwarding
+// goroutine.
+func ChanUniqBy[T any, K comparable](ctx context.Context, input <-chan T, keySelector KeySelector[T, K]) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		seen := UniqFilter(keySelector)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case v, ok := <-input:
+				if !ok {
+					return
+				}
+				if !seen(v) {
+					continue
+				}
+				select {
+				case out <- v:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return out
+}
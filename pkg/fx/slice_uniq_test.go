@@ -0,0 +1,79 @@
+package fx
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSliceUniq(t *testing.T) {
+	cases := []struct {
+		name  string
+		input []string
+		want  []string
+	}{
+		{name: "empty slice produce an empty slice", input: []string{}, want: []string{}},
+		{name: "nil slice produce an empty slice", input: nil, want: []string{}},
+		{name: "duplicates are removed keeping first seen order", input: []string{"b", "a", "b", "c", "a"}, want: []string{"b", "a", "c"}},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, SliceUniq(tt.input))
+		})
+	}
+}
+
+func TestSliceUniqBy(t *testing.T) {
+	input := []string{"Go", "rust", "GO", "Rust", "zig"}
+
+	require.Equal(t, []string{"Go", "rust", "zig"}, SliceUniqBy(input, strings.ToLower))
+	require.Equal(t, [][]string{{"Go", "GO"}, {"rust", "Rust"}}, SliceDuplicates(input, strings.ToLower))
+	require.Equal(t, map[string]int{"go": 2, "rust": 2, "zig": 1}, SliceCountBy(input, strings.ToLower))
+
+	require.Equal(t, [][]string{}, SliceDuplicates([]string{"a", "b"}, strings.ToLower))
+	require.Equal(t, map[string]int{}, SliceCountBy(nil, strings.ToLower))
+}
+
+func TestStreamingUniq(t *testing.T) {
+	seen := UniqFilter(func(i int) int { return i % 3 })
+	kept := []int{}
+	for i := 0; i < 10; i++ {
+		if seen(i) {
+			kept = append(kept, i)
+		}
+	}
+	require.Equal(t, []int{0, 1, 2}, kept)
+
+	input := make(chan string)
+	go func() {
+		defer close(input)
+		for _, s := range []string{"a", "A", "b", "a", "c"} {
+			input <- s
+		}
+	}()
+	got := []string{}
+	for s := range ChanUniqBy(context.Background(), input, strings.ToLower) {
+		got = append(got, s)
+	}
+	require.Equal(t, []string{"a", "b", "c"}, got)
+}
+
+func TestChanUniqByStopsOnCancel(t *testing.T) {
+	input := make(chan int)
+	ctx, cancel := context.WithCancel(context.Background())
+	out := ChanUniqBy(ctx, input, func(i int) int { return i })
+
+	input <- 1
+	require.Equal(t, 1, <-out)
+	input <- 2 // the goroutine now waits to forward it
+	cancel()
+	for range out {
+	}
+	select {
+	case input <- 3:
+		t.Fatal("input is still read after cancellation")
+	default:
+	}
+}
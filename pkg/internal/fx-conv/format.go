@@ -0,0 +1,62 @@
+package fxconv
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Directive rebuilds the formatting directive handed to a fmt.Formatter so it
+// can be applied to a wrapped value.
+func Directive(f fmt.State, verb rune) string {
+	var b strings.Builder
+	b.WriteByte('%')
+	for _, flag := range "+-# 0" {
+		if f.Flag(int(flag)) {
+			b.WriteRune(flag)
+		}
+	}
+	if width, ok := f.Width(); ok {
+		b.WriteString(strconv.Itoa(width))
+	}
+	if precision, ok := f.Precision(); ok {
+		b.WriteByte('.')
+		b.WriteString(strconv.Itoa(precision))
+	}
+	b.WriteRune(verb)
+	return b.String()
+}
+
+// TypeName returns the name of T, including when T is an interface type.
+func TypeName[T any]() string {
+	return reflect.TypeOf((*T)(nil)).Elem().String()
+}
+
+// ErrorChain renders err followed by every error it wraps, each prefixed by its
+// type. The outermost error is formatted with %+v so details such as stack
+// traces are kept. Errors wrapping several errors through Unwrap() []error, such
+// as fxerror.MultiError, are walked depth first.
+func ErrorChain(err error) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%T: %+v", err, err)
+	writeCauses(&b, err)
+	return b.String()
+}
+
+func writeCauses(b *strings.Builder, err error) {
+	var causes []error
+	switch wrapper := err.(type) {
+	case interface{ Unwrap() []error }:
+		causes = wrapper.Unwrap()
+	case interface{ Unwrap() error }:
+		causes = []error{wrapper.Unwrap()}
+	}
+	for _, cause := range causes {
+		if cause == nil {
+			continue
+		}
+		fmt.Fprintf(b, "; %T: %s", cause, cause.Error())
+		writeCauses(b, cause)
+	}
+}
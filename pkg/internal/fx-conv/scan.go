@@ -0,0 +1,202 @@
+package fxconv
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Scan assigns a value read from a database driver to dest, which must be a
+// non-nil pointer. It supports the common driver value types (int64, float64,
+// bool, []byte, string and time.Time) converted into strings, integers, floats,
+// booleans, byte slices and time.Time, including named types built on them.
+// Text is converted into time.Time when it is formatted as RFC 3339 or as the
+// "2006-01-02 15:04:05" layout used by SQLite and MySQL.
+// When dest implements sql.Scanner the conversion is delegated to it.
+func Scan(dest any, src any) error {
+	if scanner, ok := dest.(sql.Scanner); ok {
+		return scanner.Scan(src)
+	}
+
+	dv := reflect.ValueOf(dest)
+	if dv.Kind() != reflect.Pointer || dv.IsNil() {
+		return fmt.Errorf("scan destination must be a non-nil pointer, got %T", dest)
+	}
+	dv = dv.Elem()
+
+	if src == nil {
+		dv.Set(reflect.Zero(dv.Type()))
+		return nil
+	}
+
+	sv := reflect.ValueOf(src)
+	if sv.Type().AssignableTo(dv.Type()) {
+		if b, ok := src.([]byte); ok {
+			// drivers may reuse the buffer once Next is called again
+			sv = reflect.ValueOf(append([]byte(nil), b...))
+		}
+		dv.Set(sv)
+		return nil
+	}
+
+	if dv.Type().ConvertibleTo(timeType) {
+		t, ok := src.(time.Time)
+		if !ok {
+			s, isText := src.(string)
+			if b, isBytes := src.([]byte); isBytes {
+				s, isText = string(b), true
+			}
+			if !isText {
+				return errUnsupported(src, dest)
+			}
+			var err error
+			if t, err = parseTime(s); err != nil {
+				return fmt.Errorf("converting %T %q to %s: %w", src, s, dv.Type(), err)
+			}
+		}
+		dv.Set(reflect.ValueOf(t).Convert(dv.Type()))
+		return nil
+	}
+
+	switch dv.Kind() {
+	case reflect.String:
+		s, ok := asString(src)
+		if !ok {
+			return errUnsupported(src, dest)
+		}
+		dv.SetString(s)
+		return nil
+	case reflect.Slice:
+		if dv.Type().Elem().Kind() != reflect.Uint8 {
+			break
+		}
+		switch v := src.(type) {
+		case []byte:
+			dv.Set(reflect.ValueOf(append([]byte(nil), v...)).Convert(dv.Type()))
+			return nil
+		case string:
+			dv.Set(reflect.ValueOf([]byte(v)).Convert(dv.Type()))
+			return nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		s, ok := asString(src)
+		if !ok {
+			return errUnsupported(src, dest)
+		}
+		i, err := strconv.ParseInt(s, 10, dv.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("converting %T %q to %s: %w", src, s, dv.Type(), err)
+		}
+		dv.SetInt(i)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		s, ok := asString(src)
+		if !ok {
+			return errUnsupported(src, dest)
+		}
+		u, err := strconv.ParseUint(s, 10, dv.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("converting %T %q to %s: %w", src, s, dv.Type(), err)
+		}
+		dv.SetUint(u)
+		return nil
+	case reflect.Float32, reflect.Float64:
+		s, ok := asString(src)
+		if !ok {
+			return errUnsupported(src, dest)
+		}
+		f, err := strconv.ParseFloat(s, dv.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("converting %T %q to %s: %w", src, s, dv.Type(), err)
+		}
+		dv.SetFloat(f)
+		return nil
+	case reflect.Bool:
+		switch v := src.(type) {
+		case bool:
+			dv.SetBool(v)
+			return nil
+		case int64:
+			if v != 0 && v != 1 {
+				return fmt.Errorf("converting %T %d to %s: value out of range", src, v, dv.Type())
+			}
+			dv.SetBool(v == 1)
+			return nil
+		}
+		s, ok := asString(src)
+		if !ok {
+			return errUnsupported(src, dest)
+		}
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return fmt.Errorf("converting %T %q to %s: %w", src, s, dv.Type(), err)
+		}
+		dv.SetBool(b)
+		return nil
+	}
+
+	if sv.Type().ConvertibleTo(dv.Type()) && sv.Kind() == dv.Kind() {
+		dv.Set(sv.Convert(dv.Type()))
+		return nil
+	}
+	return errUnsupported(src, dest)
+}
+
+// timeLayouts are the textual time formats of drivers storing times as text,
+// such as SQLite, or MySQL without parseTime.
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02",
+}
+
+// parseTime parses s with the first matching layout of timeLayouts, times
+// without a zone are read as UTC.
+func parseTime(s string) (time.Time, error) {
+	var err error
+	for _, layout := range timeLayouts {
+		var t time.Time
+		if t, err = time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, err
+}
+
+// asString renders the scalar driver values as their textual representation.
+func asString(src any) (string, bool) {
+	switch v := src.(type) {
+	case string:
+		return v, true
+	case []byte:
+		return string(v), true
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64), true
+	case bool:
+		return strconv.FormatBool(v), true
+	case time.Time:
+		return v.Format(time.RFC3339Nano), true
+	}
+	sv := reflect.ValueOf(src)
+	switch sv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(sv.Int(), 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(sv.Uint(), 10), true
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(sv.Float(), 'g', -1, sv.Type().Bits()), true
+	}
+	return "", false
+}
+
+func errUnsupported(src any, dest any) error {
+	return fmt.Errorf("unsupported scan, storing driver value of type %T into type %T", src, dest)
+}
@@ -0,0 +1,107 @@
+package fxconv
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// ParseText decodes text into dest, which must be a non-nil pointer. It
+// delegates to encoding.TextUnmarshaler when dest implements it and otherwise
+// parses strings, byte slices, booleans, numbers and time.Duration.
+func ParseText(dest any, text []byte) error {
+	if u, ok := dest.(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText(text)
+	}
+
+	dv := reflect.ValueOf(dest)
+	if dv.Kind() != reflect.Pointer || dv.IsNil() {
+		return fmt.Errorf("text destination must be a non-nil pointer, got %T", dest)
+	}
+	dv = dv.Elem()
+	s := string(text)
+
+	if dv.Type() == durationType {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		dv.SetInt(int64(d))
+		return nil
+	}
+
+	switch dv.Kind() {
+	case reflect.String:
+		dv.SetString(s)
+		return nil
+	case reflect.Slice:
+		if dv.Type().Elem().Kind() != reflect.Uint8 {
+			break
+		}
+		dv.SetBytes(append([]byte(nil), text...))
+		return nil
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		dv.SetBool(b)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, dv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		dv.SetInt(i)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(s, 10, dv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		dv.SetUint(u)
+		return nil
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, dv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		dv.SetFloat(f)
+		return nil
+	}
+	return fmt.Errorf("unsupported text decoding into type %T", dest)
+}
+
+// FormatText is the counterpart of ParseText.
+func FormatText(v any) ([]byte, error) {
+	if m, ok := v.(encoding.TextMarshaler); ok {
+		return m.MarshalText()
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String:
+		return []byte(rv.String()), nil
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() != reflect.Uint8 {
+			break
+		}
+		return append([]byte(nil), rv.Bytes()...), nil
+	case reflect.Bool:
+		return strconv.AppendBool(nil, rv.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if rv.Type() == durationType {
+			return []byte(time.Duration(rv.Int()).String()), nil
+		}
+		return strconv.AppendInt(nil, rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.AppendUint(nil, rv.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.AppendFloat(nil, rv.Float(), 'g', -1, rv.Type().Bits()), nil
+	}
+	return nil, fmt.Errorf("unsupported text encoding of type %T", v)
+}